@@ -0,0 +1,120 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testStore runs the behaviour every Store must have
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+	key := "ab12cd34"
+	content := []byte("hello, blob store")
+
+	exists, err := store.Exists(ctx, key)
+	if err != nil || exists {
+		t.Fatalf("Exists before Put = %v, %v", exists, err)
+	}
+	if _, err := store.Get(ctx, key, 0); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get of a missing object returned %v, want ErrNotFound", err)
+	}
+
+	if err := store.Put(ctx, key, bytes.NewReader(content), int64(len(content))); err != nil {
+		t.Fatal(err)
+	}
+	exists, err = store.Exists(ctx, key)
+	if err != nil || !exists {
+		t.Fatalf("Exists after Put = %v, %v", exists, err)
+	}
+	if got := read(t, store, key, 0); got != string(content) {
+		t.Fatalf("Get returned %q, want %q", got, content)
+	}
+	if got := read(t, store, key, 7); got != string(content[7:]) {
+		t.Fatalf("Get from offset 7 returned %q, want %q", got, content[7:])
+	}
+
+	// Writing the key again replaces the object
+	if err := store.Put(ctx, key, strings.NewReader("replaced"), 8); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(t, store, key, 0); got != "replaced" {
+		t.Fatalf("Get after replacing returned %q", got)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	exists, err = store.Exists(ctx, key)
+	if err != nil || exists {
+		t.Fatalf("Exists after Delete = %v, %v", exists, err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("deleting a missing object returned %v", err)
+	}
+}
+
+func read(t *testing.T, store Store, key string, offset int64) string {
+	t.Helper()
+	r, err := store.Get(context.Background(), key, offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+}
+
+func TestFileStoreRejectsShortContent(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Put(context.Background(), "ab12", strings.NewReader("short"), 10)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Put returned %v, want io.ErrUnexpectedEOF", err)
+	}
+	// A failed write leaves nothing behind
+	if exists, _ := store.Exists(context.Background(), "ab12"); exists {
+		t.Fatal("partially written object is visible")
+	}
+}
+
+func TestMaxObjectSize(t *testing.T) {
+	store, err := Open(Config{Backend: "local", Dir: t.TempDir(), MaxObjectSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "ab12", strings.NewReader("123456789"), 9); !errors.Is(err, ErrTooLarge) {
+		t.Fatalf("Put of 9 bytes returned %v, want ErrTooLarge", err)
+	}
+	if err := store.Put(ctx, "ab12", strings.NewReader("12345678"), 8); err != nil {
+		t.Fatalf("Put of 8 bytes returned %v", err)
+	}
+
+	// A reader longer than the announced size is cut at it
+	if err := store.Put(ctx, "cd34", strings.NewReader("1234567890"), 4); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(t, store, "cd34", 0); got != "1234" {
+		t.Fatalf("Get returned %q, want the announced 4 bytes", got)
+	}
+}
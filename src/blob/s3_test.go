@@ -0,0 +1,30 @@
+package blob
+
+import (
+	"os"
+	"testing"
+)
+
+// TestS3Store runs against a real S3-compatible service, for example a local MinIO:
+//
+//	BLOB_TEST_S3_ENDPOINT=localhost:9000 BLOB_TEST_S3_BUCKET=test \
+//	BLOB_TEST_S3_ACCESS_KEY=minioadmin BLOB_TEST_S3_SECRET_KEY=minioadmin go test ./src/blob
+func TestS3Store(t *testing.T) {
+	endpoint := os.Getenv("BLOB_TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("BLOB_TEST_S3_ENDPOINT is not set")
+	}
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  endpoint,
+		Bucket:    os.Getenv("BLOB_TEST_S3_BUCKET"),
+		AccessKey: os.Getenv("BLOB_TEST_S3_ACCESS_KEY"),
+		SecretKey: os.Getenv("BLOB_TEST_S3_SECRET_KEY"),
+		Region:    os.Getenv("BLOB_TEST_S3_REGION"),
+		UseSSL:    os.Getenv("BLOB_TEST_S3_USE_SSL") == "true",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStore(t, store)
+}
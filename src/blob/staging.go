@@ -0,0 +1,86 @@
+package blob
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Staging keeps unfinished uploads as local files until they are complete,
+// the size of a file is the offset the upload continues from
+type Staging struct {
+	dir string
+}
+
+// NewStaging creates the directory if needed
+func NewStaging(dir string) (*Staging, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Staging{dir: dir}, nil
+}
+
+func (s *Staging) path(id uint) string {
+	return filepath.Join(s.dir, strconv.FormatUint(uint64(id), 10))
+}
+
+// Offset returns the number of bytes received for the upload
+func (s *Staging) Offset(id uint) (int64, error) {
+	info, err := os.Stat(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// Append opens the upload for appending, returning the offset the next byte is written at.
+// Callers must not append to the same upload concurrently.
+func (s *Staging) Append(id uint) (*os.File, int64, error) {
+	file, err := os.OpenFile(s.path(id), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+// Open opens the upload for reading
+func (s *Staging) Open(id uint) (*os.File, error) {
+	return os.Open(s.path(id))
+}
+
+// Hash returns the hex SHA-256 of the upload
+func (s *Staging) Hash(id uint) (string, error) {
+	file, err := s.Open(id)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Remove deletes the upload
+func (s *Staging) Remove(id uint) error {
+	err := os.Remove(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
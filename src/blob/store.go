@@ -0,0 +1,81 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrNotFound is returned when the object does not exist
+	ErrNotFound = errors.New("blob not found")
+	// ErrTooLarge is returned when the object exceeds the size limit of the store
+	ErrTooLarge = errors.New("blob exceeds the size limit")
+)
+
+// Store keeps immutable objects addressed by key.
+// Writing an existing key replaces the object, readers never see a partially written object.
+type Store interface {
+	// Put stores size bytes read from r
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get opens the object for reading from the offset
+	Get(ctx context.Context, key string, offset int64) (io.ReadCloser, error)
+	// Exists checks if the object is stored
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete removes the object, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects and configures the store
+type Config struct {
+	// Backend is "local" or "s3"
+	Backend string
+	// Dir keeps the objects of the local backend
+	Dir string
+	S3  S3Config
+	// MaxObjectSize rejects larger objects, 0 means unlimited
+	MaxObjectSize int64
+}
+
+// Open creates the store selected by the config
+func Open(config Config) (Store, error) {
+	var (
+		store Store
+		err   error
+	)
+	switch config.Backend {
+	case "", "local":
+		store, err = NewFileStore(config.Dir)
+	case "s3":
+		store, err = NewS3Store(config.S3)
+	default:
+		return nil, fmt.Errorf("unknown blob store backend %q", config.Backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MaxObjectSize > 0 {
+		store = Limit(store, config.MaxObjectSize)
+	}
+	return store, nil
+}
+
+// Limit rejects objects larger than max bytes with ErrTooLarge
+func Limit(store Store, max int64) Store {
+	return &limitedStore{Store: store, max: max}
+}
+
+type limitedStore struct {
+	Store
+	max int64
+}
+
+func (s *limitedStore) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if size > s.max {
+		return ErrTooLarge
+	}
+	// A reader longer than announced must not slip past the limit
+	return s.Store.Put(ctx, key, io.LimitReader(r, size), size)
+}
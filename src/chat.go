@@ -0,0 +1,742 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/data"
+	"alexchatapp/src/filetype"
+	"alexchatapp/src/hub"
+	"alexchatapp/src/jwt"
+	"alexchatapp/src/linkpreview"
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/pubsub"
+	"alexchatapp/src/typing"
+	"alexchatapp/src/utils"
+	"alexchatapp/src/voice"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// previewLength is the maximum number of characters of a message shown in the chat list
+const previewLength = 100
+
+// ChatServer implements ChatService from proto file
+type ChatServer struct {
+	pb.UnimplementedChatServiceServer
+	chat_repo  *data.ChatRepository
+	auth_repo  *data.UsersRepository
+	media_repo *data.MediaRepository
+	chat_hub   *hub.Hub
+	broker     pubsub.Broker
+	config     ChatConfig
+	typing     *typing.Tracker
+	// previews fetches link previews, nil when they are disabled
+	previews linkpreview.Fetcher
+	// chat_types caches the type of every chat seen, chat types never change
+	chat_types sync.Map
+	// unfurling shares a fetch of a link between messages sent at the same time
+	unfurling singleflight.Group
+	// store_inline stores content sent inline in a message as media, nil until SetInlineStore
+	store_inline func(ctx context.Context, owner_id uint, content []byte, mime_prefix string) (*models.Media, error)
+}
+
+// NewChatServer creates a new chat server instance.
+// Messages are published to the broker, which delivers them to the hub of every node.
+func NewChatServer(chat_repo *data.ChatRepository, auth_repo *data.UsersRepository, media_repo *data.MediaRepository, chat_hub *hub.Hub, broker pubsub.Broker, config ChatConfig) *ChatServer {
+	server := &ChatServer{
+		chat_repo:  chat_repo,
+		auth_repo:  auth_repo,
+		media_repo: media_repo,
+		chat_hub:   chat_hub,
+		broker:     broker,
+		config:     config,
+	}
+	server.typing = typing.NewTracker(config.TypingTimeout, server.notifyTyping)
+	if config.LinkPreviews {
+		server.previews = linkpreview.NewHTTPFetcher(config.LinkPreview)
+	}
+	return server
+}
+
+// SetInlineStore sets how content sent inline in messages is stored as media.
+// The media server is created after the chat server, which it tells about scan results.
+func (s *ChatServer) SetInlineStore(store_inline func(ctx context.Context, owner_id uint, content []byte, mime_prefix string) (*models.Media, error)) {
+	s.store_inline = store_inline
+}
+
+// DeliverEvent passes an event received from the broker to the local streams
+func (s *ChatServer) DeliverEvent(event *pubsub.Event) {
+	pubsub.Dispatch(s.chat_hub, event)
+}
+
+// sendMessage stores a message of the user and delivers it to the chat participants
+func (s *ChatServer) sendMessage(ctx context.Context, userID uint, in *pb.ChatMessage) (*models.Message, error) {
+	message, err := s.saveMessage(ctx, userID, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sending a message ends typing in the chat
+	s.typing.Stop(message.ChatID, userID)
+
+	event := &pb.ServerEvent{Event: &pb.ServerEvent_Message{Message: messageToProto(message)}}
+	if err := s.publishToChat(ctx, message.ChatID, event); err != nil {
+		return nil, err
+	}
+	s.unfurlLinks(message)
+	return message, nil
+}
+
+// publishToChat sends the event to the streams of every chat participant.
+// Channel events go to the streams following the channel, so subscribers are never loaded for a post.
+func (s *ChatServer) publishToChat(ctx context.Context, chatID uint, event *pb.ServerEvent) error {
+	chatType, err := s.chatType(chatID)
+	if err != nil {
+		return err
+	}
+	if chatType == models.ChatTypeChannel {
+		if err := s.broker.Publish(ctx, &pubsub.Event{ChannelID: chatID, Payload: event}); err != nil {
+			log.Printf("Publish error: %v", err)
+			return status.Error(codes.Internal, "failed to deliver message")
+		}
+		return nil
+	}
+
+	participants, err := s.chat_repo.GetParticipantIDs(chatID)
+	if err != nil {
+		log.Printf("GetParticipantIDs error: %v", err)
+		return status.Error(codes.Internal, "failed to deliver message")
+	}
+	return s.publish(ctx, participants, event)
+}
+
+// publish sends the event to the streams of the users on every node
+func (s *ChatServer) publish(ctx context.Context, user_ids []uint, event *pb.ServerEvent) error {
+	if err := s.broker.Publish(ctx, &pubsub.Event{UserIDs: user_ids, Payload: event}); err != nil {
+		log.Printf("Publish error: %v", err)
+		return status.Error(codes.Internal, "failed to deliver message")
+	}
+	return nil
+}
+
+// updateFollowers makes the streams of the users on every node follow or unfollow the chat if it is a channel
+func (s *ChatServer) updateFollowers(ctx context.Context, chatID uint, user_ids []uint, follow bool) error {
+	chatType, err := s.chatType(chatID)
+	if err != nil || chatType != models.ChatTypeChannel || len(user_ids) == 0 {
+		return err
+	}
+
+	if err := s.broker.Publish(ctx, &pubsub.Event{UserIDs: user_ids, ChannelID: chatID, Follow: &follow}); err != nil {
+		log.Printf("Publish error: %v", err)
+		return status.Error(codes.Internal, "failed to update channel subscription")
+	}
+	return nil
+}
+
+// chatType returns the type of the chat
+func (s *ChatServer) chatType(chatID uint) (string, error) {
+	if cached, ok := s.chat_types.Load(chatID); ok {
+		return cached.(string), nil
+	}
+
+	chat, err := s.chat_repo.GetChatByID(chatID)
+	if err != nil {
+		log.Printf("GetChatByID error: %v", err)
+		return "", status.Error(codes.Internal, "failed to load chat")
+	}
+	s.chat_types.Store(chatID, chat.Type)
+	return chat.Type, nil
+}
+
+// GetChats returns the chats of the authenticated user.
+// GetChatsRequest.user_id is ignored, the user is always taken from the token.
+func (s *ChatServer) GetChats(ctx context.Context, req *pb.GetChatsRequest) (*pb.GetChatsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor *data.ChatCursor
+	if req.Cursor != "" {
+		decoded, err := data.DecodeChatCursor(req.Cursor)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		cursor = &decoded
+	}
+
+	summaries, hasMore, err := s.chat_repo.GetChatSummaries(userID, cursor, int(req.Count))
+	if err != nil {
+		log.Printf("GetChats error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load chats")
+	}
+
+	response := &pb.GetChatsResponse{HasMore: hasMore}
+	for i := range summaries {
+		response.Chats = append(response.Chats, chatSummaryToProto(&summaries[i]))
+	}
+	if len(summaries) > 0 {
+		response.NextCursor = summaries[len(summaries)-1].Cursor().Encode()
+	}
+	return response, nil
+}
+
+// GetMessages returns the history of a chat the authenticated user belongs to
+func (s *ChatServer) GetMessages(ctx context.Context, req *pb.GetMessagesRequest) (*pb.GetMessagesResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkMembership(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		messages  []models.Message
+		hasBefore bool
+		hasAfter  bool
+	)
+
+	switch {
+	case req.AroundMessageId != nil:
+		messageID, err := utils.ParseID(*req.AroundMessageId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		messages, hasBefore, hasAfter, err = s.chat_repo.GetMessagesAround(chatID, userID, messageID, int(req.Count))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Error(codes.NotFound, "message not found")
+		}
+		if err != nil {
+			log.Printf("GetMessagesAround error: %v", err)
+			return nil, status.Error(codes.Internal, "failed to load messages")
+		}
+
+	default:
+		var cursor *data.MessageCursor
+		if req.Cursor != "" {
+			decoded, err := data.DecodeMessageCursor(req.Cursor)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			cursor = &decoded
+		} else if req.BeforeTimestamp > 0 {
+			cursor = &data.MessageCursor{CreatedAt: time.UnixMilli(req.BeforeTimestamp)}
+		}
+
+		direction := data.PageBackward
+		if req.Direction == pb.GetMessagesRequest_FORWARD {
+			direction = data.PageForward
+		}
+
+		var hasMore bool
+		messages, hasMore, err = s.chat_repo.GetMessagesPage(chatID, userID, cursor, direction, int(req.Count))
+		if err != nil {
+			log.Printf("GetMessagesPage error: %v", err)
+			return nil, status.Error(codes.Internal, "failed to load messages")
+		}
+
+		// Paging away from a cursor means the cursor message lies on the other side
+		if direction == data.PageForward {
+			hasAfter, hasBefore = hasMore, cursor != nil
+		} else {
+			hasBefore, hasAfter = hasMore, cursor != nil
+		}
+	}
+
+	history, err := s.messagesToProto(messages, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetMessagesResponse{
+		Messages:      history,
+		HasMoreBefore: hasBefore,
+		HasMoreAfter:  hasAfter,
+	}
+	if len(messages) > 0 {
+		response.PrevCursor = data.CursorOf(&messages[0]).Encode()
+		response.NextCursor = data.CursorOf(&messages[len(messages)-1]).Encode()
+	} else {
+		response.PrevCursor = req.Cursor
+		response.NextCursor = req.Cursor
+	}
+	return response, nil
+}
+
+// CreateChat creates a new group or channel with the authenticated user as its owner
+func (s *ChatServer) CreateChat(ctx context.Context, req *pb.CreateChatRequest) (*pb.CreateChatResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateChatName(req.Name); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := utils.ValidateChatDescription(req.Description); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	chatType := models.ChatTypeGroup
+	switch req.Type {
+	case pb.ChatType_GROUP:
+	case pb.ChatType_CHANNEL:
+		chatType = models.ChatTypeChannel
+	default:
+		return nil, status.Error(codes.InvalidArgument, "type must be GROUP or CHANNEL, use GetOrCreateDirectChat for direct chats")
+	}
+
+	participants, err := s.parseUserIDs(req.ParticipantsIds)
+	if err != nil {
+		return nil, err
+	}
+
+	chat := &models.Chat{
+		Type:        chatType,
+		Name:        req.Name,
+		Description: req.Description,
+		CreatorID:   userID,
+	}
+	if err := s.chat_repo.CreateChat(chat, participants); err != nil {
+		log.Printf("CreateChat error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to create chat")
+	}
+
+	var members []uint
+	for _, participant := range chat.Participants {
+		members = append(members, participant.UserID)
+	}
+	if err := s.updateFollowers(ctx, chat.ID, members, true); err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateChatResponse{
+		ChatId: utils.FormatID(chat.ID),
+	}, nil
+}
+
+// GetOrCreateDirectChat returns the direct chat with another user, creating it on first use
+func (s *ChatServer) GetOrCreateDirectChat(ctx context.Context, req *pb.GetOrCreateDirectChatRequest) (*pb.GetOrCreateDirectChatResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := s.parseUserIDs([]string{req.TargetUserId})
+	if err != nil {
+		return nil, err
+	}
+	if targets[0] == userID {
+		return nil, status.Error(codes.InvalidArgument, "direct chat needs another user")
+	}
+
+	chat, created, err := s.chat_repo.GetOrCreateDirectChat(userID, targets[0])
+	if err != nil {
+		log.Printf("GetOrCreateDirectChat error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to create chat")
+	}
+
+	peers, err := s.chat_repo.GetDirectPeers([]uint{chat.ID}, userID)
+	if err != nil {
+		log.Printf("GetDirectPeers error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load chat")
+	}
+
+	result := chatToProto(chat)
+	result.Name = peers[chat.ID].Name
+	result.DirectUserId = utils.FormatID(targets[0])
+	result.LastActivity = chat.LastActivityAt.UnixMilli()
+	return &pb.GetOrCreateDirectChatResponse{
+		Chat:    result,
+		Created: created,
+	}, nil
+}
+
+// parseUserIDs parses user ids from a request and makes sure the users exist
+func (s *ChatServer) parseUserIDs(rawIDs []string) ([]uint, error) {
+	var userIDs []uint
+	for _, rawID := range rawIDs {
+		userID, err := utils.ParseID(rawID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if _, err := s.auth_repo.GetUserByID(userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, status.Error(codes.NotFound, "user not found: "+rawID)
+			}
+			return nil, status.Error(codes.Internal, "failed to check participants")
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+// saveMessage validates an incoming message and stores it on behalf of the sender
+func (s *ChatServer) saveMessage(ctx context.Context, senderID uint, in *pb.ChatMessage) (*models.Message, error) {
+	participant, err := s.checkRole(in.ChatId, senderID)
+	if err != nil {
+		return nil, err
+	}
+	chatID := participant.ChatID
+
+	if !isAdminRole(participant.Role) {
+		chatType, err := s.chatType(chatID)
+		if err != nil {
+			return nil, err
+		}
+		if chatType == models.ChatTypeChannel {
+			return nil, status.Error(codes.PermissionDenied, "only channel admins can post")
+		}
+	}
+
+	message := &models.Message{
+		ChatID:   chatID,
+		SenderID: senderID,
+		Status:   models.MessageStatusSent,
+	}
+
+	if in.ReplyToId != "" {
+		quoted, err := s.findMessage(chatID, in.ReplyToId)
+		if err != nil {
+			return nil, err
+		}
+		if quoted.DeletedAt != nil {
+			return nil, status.Error(codes.FailedPrecondition, "message is deleted")
+		}
+
+		// Replies to replies stay in the thread of the first message
+		rootID := quoted.ID
+		if quoted.ThreadRootID != nil {
+			rootID = *quoted.ThreadRootID
+		}
+		message.ReplyToID = &quoted.ID
+		message.ThreadRootID = &rootID
+		message.ReplyTo = quoted
+	}
+
+	switch content := in.Content.(type) {
+	case *pb.ChatMessage_Text:
+		if err := utils.ValidateMessageText(content.Text); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		message.Kind = models.MessageKindText
+		message.Text = content.Text
+
+		mentioned, err := s.chat_repo.FindParticipantsByUsernames(chatID, utils.ExtractMentions(content.Text))
+		if err != nil {
+			log.Printf("FindParticipantsByUsernames error: %v", err)
+			return nil, status.Error(codes.Internal, "failed to save message")
+		}
+		for _, mentionedID := range mentioned {
+			message.Mentions = append(message.Mentions, models.MessageMention{UserID: mentionedID})
+		}
+	// Inline content is stored as media, so it passes the checks and processing of uploads
+	case *pb.ChatMessage_AudioData:
+		mimeType := voice.MimeType(content.AudioData)
+		if mimeType == "" {
+			return nil, status.Error(codes.InvalidArgument, "voice message must be Ogg Opus or PCM WAV")
+		}
+		media, err := s.storeInline(ctx, senderID, content.AudioData, mimeType, "audio/")
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkVoice(media); err != nil {
+			return nil, err
+		}
+		message.Kind = models.MessageKindAudio
+		message.MediaID = &media.ID
+		message.Media = media
+	case *pb.ChatMessage_ImageData:
+		media, err := s.storeInline(ctx, senderID, content.ImageData, filetype.Sniff(content.ImageData), "image/")
+		if err != nil {
+			return nil, err
+		}
+		message.Kind = models.MessageKindImage
+		message.MediaID = &media.ID
+		message.Media = media
+	case *pb.ChatMessage_Image:
+		media, err := s.attachMedia(senderID, content.Image.GetMediaId(), "image/")
+		if err != nil {
+			return nil, err
+		}
+		message.Kind = models.MessageKindImage
+		message.MediaID = &media.ID
+		message.Media = media
+	case *pb.ChatMessage_Audio:
+		media, err := s.attachMedia(senderID, content.Audio.GetMediaId(), "audio/")
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkVoice(media); err != nil {
+			return nil, err
+		}
+		message.Kind = models.MessageKindAudio
+		message.MediaID = &media.ID
+		message.Media = media
+	case *pb.ChatMessage_File:
+		if err := utils.ValidateFilename(content.File.GetFilename()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		media, err := s.attachMedia(senderID, content.File.GetMediaId(), "")
+		if err != nil {
+			return nil, err
+		}
+		message.Kind = models.MessageKindFile
+		message.MediaID = &media.ID
+		message.Media = media
+		message.Filename = content.File.Filename
+	case *pb.ChatMessage_System:
+		return nil, status.Error(codes.InvalidArgument, "system messages are posted by the server")
+	default:
+		return nil, status.Error(codes.InvalidArgument, "message content is required")
+	}
+
+	if err := s.chat_repo.CreateMessage(message); err != nil {
+		log.Printf("CreateMessage error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to save message")
+	}
+
+	// A scan finishing before the message existed did not announce its result to the chat
+	if message.Media != nil && message.Media.ScanStatus == models.ScanPending {
+		if media, err := s.media_repo.GetMedia(message.Media.ID); err == nil {
+			message.Media = media
+		}
+	}
+	return message, nil
+}
+
+// messagesToProto converts history as seen by the viewer: with reactions, quoted messages and thread counters
+func (s *ChatServer) messagesToProto(messages []models.Message, viewerID uint) ([]*pb.ChatMessage, error) {
+	var messageIDs, rootIDs []uint
+	for i := range messages {
+		messageIDs = append(messageIDs, messages[i].ID)
+		if messages[i].ReplyCount > 0 {
+			rootIDs = append(rootIDs, messages[i].ID)
+		}
+	}
+
+	reactions, err := s.chat_repo.GetReactionSummaries(messageIDs, viewerID)
+	if err != nil {
+		log.Printf("GetReactionSummaries error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load messages")
+	}
+	threadUnread, err := s.chat_repo.GetThreadUnreadCounts(rootIDs, viewerID)
+	if err != nil {
+		log.Printf("GetThreadUnreadCounts error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load messages")
+	}
+	if err := s.chat_repo.LoadReplyQuotes(messages); err != nil {
+		log.Printf("LoadReplyQuotes error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load messages")
+	}
+	if err := s.media_repo.LoadMessageMedia(messages); err != nil {
+		log.Printf("LoadMessageMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load messages")
+	}
+	previews, err := s.cachedLinkPreviews(messages)
+	if err != nil {
+		log.Printf("GetLinkPreviews error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load messages")
+	}
+
+	result := make([]*pb.ChatMessage, 0, len(messages))
+	for i := range messages {
+		message := messageToProto(&messages[i])
+		message.ThreadUnreadCount = int32(threadUnread[messages[i].ID])
+		if !message.Deleted {
+			message.Reactions = reactionsToProto(reactions[messages[i].ID])
+			message.LinkPreviews = previews[messages[i].ID]
+		}
+		result = append(result, message)
+	}
+	return result, nil
+}
+
+// checkMembership parses the chat id and makes sure the user belongs to the chat
+func (s *ChatServer) checkMembership(rawChatID string, userID uint) (uint, error) {
+	chatID, err := utils.ParseID(rawChatID)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	isMember, err := s.chat_repo.IsParticipant(chatID, userID)
+	if err != nil {
+		log.Printf("IsParticipant error: %v", err)
+		return 0, status.Error(codes.Internal, "failed to check chat membership")
+	}
+	if !isMember {
+		return 0, status.Error(codes.PermissionDenied, "user is not a member of the chat")
+	}
+	return chatID, nil
+}
+
+// checkRole parses the chat id and returns the membership of the user in the chat
+func (s *ChatServer) checkRole(rawChatID string, userID uint) (*models.ChatParticipant, error) {
+	chatID, err := utils.ParseID(rawChatID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	participant, err := s.chat_repo.GetParticipant(chatID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.PermissionDenied, "user is not a member of the chat")
+	}
+	if err != nil {
+		log.Printf("GetParticipant error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to check chat membership")
+	}
+	return participant, nil
+}
+
+// isChatAdmin checks if the user administers the chat, the owner is an admin as well
+func (s *ChatServer) isChatAdmin(chatID, userID uint) (bool, error) {
+	participant, err := s.chat_repo.GetParticipant(chatID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		log.Printf("GetParticipant error: %v", err)
+		return false, status.Error(codes.Internal, "failed to check chat membership")
+	}
+	return isAdminRole(participant.Role), nil
+}
+
+func isAdminRole(role string) bool {
+	return role == models.ChatRoleOwner || role == models.ChatRoleAdmin
+}
+
+// authenticatedUserID extracts the user id placed into the context by the JWT interceptor
+func authenticatedUserID(ctx context.Context) (uint, error) {
+	userIDValue, ok := jwt.GetUserIdFromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+	return uint(userIDValue), nil
+}
+
+func chatToProto(chat *models.Chat) *pb.Chat {
+	result := &pb.Chat{
+		Id:   utils.FormatID(chat.ID),
+		Name: chat.Name,
+		Type: chatTypeToProto(chat.Type),
+	}
+	if chat.Description != "" {
+		description := chat.Description
+		result.Description = &description
+	}
+	return result
+}
+
+func chatTypeToProto(chatType string) pb.ChatType {
+	switch chatType {
+	case models.ChatTypeDirect:
+		return pb.ChatType_DIRECT
+	case models.ChatTypeChannel:
+		return pb.ChatType_CHANNEL
+	default:
+		return pb.ChatType_GROUP
+	}
+}
+
+func chatSummaryToProto(summary *data.ChatSummary) *pb.Chat {
+	result := chatToProto(&summary.Chat)
+	result.LastActivity = summary.LastActivityAt.UnixMilli()
+	result.UnreadCount = int32(summary.UnreadCount)
+	result.MentionCount = int32(summary.MentionCount)
+	result.ThreadUnreadCount = int32(summary.ThreadUnreadCount)
+	if summary.DirectUserID != 0 {
+		result.DirectUserId = utils.FormatID(summary.DirectUserID)
+	}
+	if summary.LastMessage != nil {
+		result.LastMessage = messagePreviewToProto(summary.LastMessage)
+	}
+	return result
+}
+
+// messagePreviewToProto builds a short preview of the message for the chat list
+func messagePreviewToProto(message *models.Message) *pb.MessagePreview {
+	var text string
+	switch {
+	case message.DeletedAt != nil:
+		text = "[Deleted message]"
+	case message.Kind == models.MessageKindAudio:
+		text = "[Voice message]"
+	case message.Kind == models.MessageKindImage:
+		text = "[Photo]"
+	case message.Kind == models.MessageKindFile:
+		text = "[File] " + message.Filename
+	case message.Kind == models.MessageKindSystem:
+		text = systemPreview(message.System)
+	default:
+		text = utils.TruncateText(message.Text, previewLength)
+	}
+
+	return &pb.MessagePreview{
+		MessageId: utils.FormatID(message.ID),
+		SenderId:  utils.FormatID(message.SenderID),
+		Text:      text,
+		Timestamp: message.CreatedAt.UnixMilli(),
+	}
+}
+
+func messageToProto(message *models.Message) *pb.ChatMessage {
+	result := &pb.ChatMessage{
+		Id:            utils.FormatID(message.ID),
+		ChatId:        utils.FormatID(message.ChatID),
+		SenderId:      utils.FormatID(message.SenderID),
+		Timestamp:     message.CreatedAt.UnixMilli(),
+		MessageStatus: pb.ChatMessageStatus(message.Status),
+	}
+	if message.EditedAt != nil {
+		result.EditedAt = message.EditedAt.UnixMilli()
+	}
+	if message.ThreadRootID != nil {
+		result.ThreadRootId = utils.FormatID(*message.ThreadRootID)
+	}
+	result.ReplyCount = int32(message.ReplyCount)
+	result.ViewCount = int32(message.ViewCount)
+	if message.DeletedAt != nil {
+		result.Deleted = true
+		return result
+	}
+
+	if message.ReplyToID != nil {
+		result.ReplyToId = utils.FormatID(*message.ReplyToID)
+		if message.ReplyTo != nil {
+			result.ReplyTo = messagePreviewToProto(message.ReplyTo)
+		}
+	}
+
+	switch {
+	case message.Kind == models.MessageKindAudio && message.MediaID != nil:
+		result.Content = &pb.ChatMessage_Audio{Audio: attachmentToProto(message)}
+	case message.Kind == models.MessageKindImage && message.MediaID != nil:
+		result.Content = &pb.ChatMessage_Image{Image: attachmentToProto(message)}
+	case message.Kind == models.MessageKindFile && message.MediaID != nil:
+		result.Content = &pb.ChatMessage_File{File: fileToProto(message)}
+	case message.Kind == models.MessageKindAudio:
+		result.Content = &pb.ChatMessage_AudioData{AudioData: message.AudioData}
+	case message.Kind == models.MessageKindImage:
+		result.Content = &pb.ChatMessage_ImageData{ImageData: message.ImageData}
+	case message.Kind == models.MessageKindSystem:
+		result.Content = &pb.ChatMessage_System{System: systemEventToProto(message.System)}
+	default:
+		result.Content = &pb.ChatMessage_Text{Text: message.Text}
+	}
+	if message.Media != nil {
+		result.AttachmentState = attachmentStateToProto(message.Media.ScanStatus)
+	}
+	return result
+}
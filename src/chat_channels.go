@@ -0,0 +1,97 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Subscribe adds the caller to the subscribers of a channel
+func (s *ChatServer) Subscribe(ctx context.Context, req *pb.SubscribeRequest) (*pb.SubscribeResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.findChannel(req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	added, err := s.chat_repo.AddSubscriber(chatID, userID)
+	if err != nil {
+		log.Printf("AddSubscriber error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to subscribe")
+	}
+	if added {
+		if err := s.updateFollowers(ctx, chatID, []uint{userID}, true); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.SubscribeResponse{}, nil
+}
+
+// Unsubscribe removes the caller from the subscribers of a channel, the owner has to transfer the channel first
+func (s *ChatServer) Unsubscribe(ctx context.Context, req *pb.UnsubscribeRequest) (*pb.UnsubscribeResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.findChannel(req.ChatId)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.chat_repo.GetParticipant(chatID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &pb.UnsubscribeResponse{}, nil
+	}
+	if err != nil {
+		log.Printf("GetParticipant error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to check chat membership")
+	}
+	if participant.Role == models.ChatRoleOwner {
+		return nil, status.Error(codes.FailedPrecondition, "the owner must transfer the channel before unsubscribing")
+	}
+
+	removed, err := s.chat_repo.RemoveSubscriber(chatID, userID)
+	if err != nil {
+		log.Printf("RemoveSubscriber error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to unsubscribe")
+	}
+	if removed {
+		if err := s.updateFollowers(ctx, chatID, []uint{userID}, false); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.UnsubscribeResponse{}, nil
+}
+
+// findChannel parses the chat id and makes sure the chat is a channel
+func (s *ChatServer) findChannel(rawChatID string) (uint, error) {
+	chatID, err := utils.ParseID(rawChatID)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	chat, err := s.chat_repo.GetChatByID(chatID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, status.Error(codes.NotFound, "channel not found")
+	}
+	if err != nil {
+		log.Printf("GetChatByID error: %v", err)
+		return 0, status.Error(codes.Internal, "failed to load chat")
+	}
+	if chat.Type != models.ChatTypeChannel {
+		return 0, status.Error(codes.FailedPrecondition, "chat is not a channel")
+	}
+	return chat.ID, nil
+}
@@ -0,0 +1,202 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EditMessage changes the text of a message and pushes the edit to the participants
+func (s *ChatServer) EditMessage(ctx context.Context, req *pb.EditMessageRequest) (*pb.EditMessageResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.editMessage(ctx, userID, req.ChatId, req.MessageId, req.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EditMessageResponse{
+		Message: messageToProto(message),
+	}, nil
+}
+
+// DeleteMessage deletes a message for the caller, or for every participant
+func (s *ChatServer) DeleteMessage(ctx context.Context, req *pb.DeleteMessageRequest) (*pb.DeleteMessageResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.deleteMessage(ctx, userID, req.ChatId, req.MessageId, req.ForEveryone); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteMessageResponse{}, nil
+}
+
+func (s *ChatServer) editMessage(ctx context.Context, userID uint, rawChatID, rawMessageID, text string) (*models.Message, error) {
+	chatID, err := s.checkMembership(rawChatID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.findMessage(chatID, rawMessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.DeletedAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "message is deleted")
+	}
+	if message.Kind != models.MessageKindText {
+		return nil, status.Error(codes.FailedPrecondition, "only text messages can be edited")
+	}
+	if err := s.checkCanModify(message, userID); err != nil {
+		return nil, err
+	}
+	if time.Since(message.CreatedAt) > s.config.EditWindow {
+		return nil, status.Error(codes.FailedPrecondition, "message can no longer be edited")
+	}
+
+	if err := utils.ValidateMessageText(text); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	mentioned, err := s.chat_repo.FindParticipantsByUsernames(chatID, utils.ExtractMentions(text))
+	if err != nil {
+		log.Printf("FindParticipantsByUsernames error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to edit message")
+	}
+
+	if err := s.chat_repo.EditMessage(message, text, userID, mentioned); err != nil {
+		log.Printf("EditMessage error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to edit message")
+	}
+
+	event := &pb.ServerEvent{Event: &pb.ServerEvent_Edit{Edit: &pb.EditEvent{
+		ChatId:    utils.FormatID(chatID),
+		MessageId: utils.FormatID(message.ID),
+		Text:      message.Text,
+		EditedAt:  message.EditedAt.UnixMilli(),
+	}}}
+	if err := s.publishToChat(ctx, chatID, event); err != nil {
+		return nil, err
+	}
+	s.unfurlLinks(message)
+	return message, nil
+}
+
+func (s *ChatServer) deleteMessage(ctx context.Context, userID uint, rawChatID, rawMessageID string, forEveryone bool) error {
+	chatID, err := s.checkMembership(rawChatID, userID)
+	if err != nil {
+		return err
+	}
+
+	message, err := s.findMessage(chatID, rawMessageID)
+	if err != nil {
+		return err
+	}
+
+	if !forEveryone {
+		if err := s.chat_repo.HideMessage(message.ID, userID); err != nil {
+			log.Printf("HideMessage error: %v", err)
+			return status.Error(codes.Internal, "failed to delete message")
+		}
+		return nil
+	}
+
+	if message.DeletedAt != nil {
+		return nil
+	}
+
+	// Admins may remove any message at any time, senders only within the edit window
+	isAdmin, err := s.isChatAdmin(chatID, userID)
+	if err != nil {
+		return err
+	}
+	if message.SenderID != userID && !isAdmin {
+		return status.Error(codes.PermissionDenied, "only the sender or a chat admin can change the message")
+	}
+	if !isAdmin && time.Since(message.CreatedAt) > s.config.EditWindow {
+		return status.Error(codes.FailedPrecondition, "message can no longer be deleted for everyone")
+	}
+
+	unpinned, err := s.chat_repo.DeleteMessageForEveryone(message)
+	if err != nil {
+		log.Printf("DeleteMessageForEveryone error: %v", err)
+		return status.Error(codes.Internal, "failed to delete message")
+	}
+
+	event := &pb.ServerEvent{Event: &pb.ServerEvent_Delete{Delete: &pb.DeleteEvent{
+		ChatId:      utils.FormatID(chatID),
+		MessageId:   utils.FormatID(message.ID),
+		ForEveryone: true,
+	}}}
+	if err := s.publishToChat(ctx, chatID, event); err != nil {
+		return err
+	}
+
+	// Pin lists update the same way as when the message is unpinned by hand
+	if unpinned {
+		return s.publishPin(ctx, chatID, message.ID, userID, true)
+	}
+	return nil
+}
+
+// GetMessageEdits returns previous versions of a message
+func (s *ChatServer) GetMessageEdits(ctx context.Context, req *pb.GetMessageEditsRequest) (*pb.GetMessageEditsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkMembership(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.findMessage(chatID, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	edits, err := s.chat_repo.GetMessageEdits(message.ID)
+	if err != nil {
+		log.Printf("GetMessageEdits error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load message edits")
+	}
+
+	response := &pb.GetMessageEditsResponse{}
+	for _, edit := range edits {
+		response.Edits = append(response.Edits, &pb.MessageEdit{
+			EditorId: utils.FormatID(edit.EditorID),
+			Text:     edit.Text,
+			EditedAt: edit.EditedAt.UnixMilli(),
+		})
+	}
+	return response, nil
+}
+
+// checkCanModify allows changing a message to its sender and to chat admins
+func (s *ChatServer) checkCanModify(message *models.Message, userID uint) error {
+	if message.SenderID == userID {
+		return nil
+	}
+
+	isAdmin, err := s.isChatAdmin(message.ChatID, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return status.Error(codes.PermissionDenied, "only the sender or a chat admin can change the message")
+	}
+	return nil
+}
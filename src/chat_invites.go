@@ -0,0 +1,249 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/data"
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// CreateInvite creates a shareable invite code for a group chat, only admins can do it
+func (s *ChatServer) CreateInvite(ctx context.Context, req *pb.CreateInviteRequest) (*pb.CreateInviteResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.checkGroupAdmin(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MaxUses < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max_uses must not be negative")
+	}
+
+	invite := &models.ChatInvite{
+		ChatID:           admin.ChatID,
+		CreatorID:        userID,
+		CreatedAt:        time.Now().Truncate(time.Microsecond),
+		MaxUses:          int(req.MaxUses),
+		RequiresApproval: req.RequiresApproval,
+	}
+	if req.ExpiresAt != 0 {
+		expiresAt := time.UnixMilli(req.ExpiresAt)
+		if !expiresAt.After(invite.CreatedAt) {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		invite.ExpiresAt = &expiresAt
+	}
+
+	if err := s.chat_repo.CreateInvite(invite); err != nil {
+		log.Printf("CreateInvite error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to create invite")
+	}
+	return &pb.CreateInviteResponse{Invite: inviteToProto(invite)}, nil
+}
+
+// RevokeInvite disables an invite code of a group chat
+func (s *ChatServer) RevokeInvite(ctx context.Context, req *pb.RevokeInviteRequest) (*pb.RevokeInviteResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.checkGroupAdmin(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	inviteID, err := utils.ParseID(req.InviteId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.chat_repo.RevokeInvite(admin.ChatID, inviteID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		log.Printf("RevokeInvite error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to revoke invite")
+	}
+	return &pb.RevokeInviteResponse{}, nil
+}
+
+// ListInvites returns the invites of a group chat that are not revoked
+func (s *ChatServer) ListInvites(ctx context.Context, req *pb.ListInvitesRequest) (*pb.ListInvitesResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.checkGroupAdmin(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invites, err := s.chat_repo.GetInvites(admin.ChatID)
+	if err != nil {
+		log.Printf("GetInvites error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load invites")
+	}
+
+	response := &pb.ListInvitesResponse{}
+	for i := range invites {
+		response.Invites = append(response.Invites, inviteToProto(&invites[i]))
+	}
+	return response, nil
+}
+
+// JoinByInvite adds the caller to the chat of an invite, or queues a join request if the invite requires approval
+func (s *ChatServer) JoinByInvite(ctx context.Context, req *pb.JoinByInviteRequest) (*pb.JoinByInviteResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "code is required")
+	}
+
+	result, err := s.chat_repo.JoinByInvite(req.Code, userID)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, status.Error(codes.NotFound, "invite not found")
+	case errors.Is(err, data.ErrInviteRevoked), errors.Is(err, data.ErrInviteExpired), errors.Is(err, data.ErrInviteExhausted):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		log.Printf("JoinByInvite error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to join chat")
+	}
+
+	if err := s.publishJoin(ctx, result); err != nil {
+		return nil, err
+	}
+	return &pb.JoinByInviteResponse{
+		ChatId:          utils.FormatID(result.ChatID),
+		PendingApproval: result.Pending,
+	}, nil
+}
+
+// ListJoinRequests returns the pending join requests of a group chat
+func (s *ChatServer) ListJoinRequests(ctx context.Context, req *pb.ListJoinRequestsRequest) (*pb.ListJoinRequestsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.checkGroupAdmin(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.chat_repo.GetPendingJoinRequests(admin.ChatID)
+	if err != nil {
+		log.Printf("GetPendingJoinRequests error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load join requests")
+	}
+
+	response := &pb.ListJoinRequestsResponse{}
+	for _, request := range requests {
+		response.Requests = append(response.Requests, &pb.JoinRequest{
+			Id:        utils.FormatID(request.ID),
+			ChatId:    utils.FormatID(request.ChatID),
+			UserId:    utils.FormatID(request.UserID),
+			InviteId:  utils.FormatID(request.InviteID),
+			CreatedAt: request.CreatedAt.UnixMilli(),
+		})
+	}
+	return response, nil
+}
+
+// ApproveJoinRequest adds the user of a pending join request to the chat
+func (s *ChatServer) ApproveJoinRequest(ctx context.Context, req *pb.DecideJoinRequestRequest) (*pb.DecideJoinRequestResponse, error) {
+	return s.decideJoinRequest(ctx, req, true)
+}
+
+// RejectJoinRequest declines a pending join request
+func (s *ChatServer) RejectJoinRequest(ctx context.Context, req *pb.DecideJoinRequestRequest) (*pb.DecideJoinRequestResponse, error) {
+	return s.decideJoinRequest(ctx, req, false)
+}
+
+func (s *ChatServer) decideJoinRequest(ctx context.Context, req *pb.DecideJoinRequestRequest, approve bool) (*pb.DecideJoinRequestResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	admin, err := s.checkGroupAdmin(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, err := utils.ParseID(req.RequestId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := s.chat_repo.DecideJoinRequest(admin.ChatID, requestID, userID, approve)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, status.Error(codes.NotFound, "join request not found")
+	case errors.Is(err, data.ErrNotPermitted):
+		return nil, status.Error(codes.PermissionDenied, "only chat admins can manage participants")
+	case errors.Is(err, data.ErrJoinRequestDecided):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, data.ErrInviteRevoked), errors.Is(err, data.ErrInviteExpired), errors.Is(err, data.ErrInviteExhausted):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		log.Printf("DecideJoinRequest error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to decide join request")
+	}
+
+	if result != nil {
+		if err := s.publishJoin(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.DecideJoinRequestResponse{}, nil
+}
+
+// publishJoin streams the chat to the user who joined and announces them if the chat does
+func (s *ChatServer) publishJoin(ctx context.Context, result *data.JoinResult) error {
+	if !result.Joined {
+		return nil
+	}
+	if err := s.updateFollowers(ctx, result.ChatID, []uint{result.UserID}, true); err != nil {
+		return err
+	}
+	if result.Message == nil {
+		return nil
+	}
+	return s.publishSystemMessages(ctx, result.ChatID, []models.Message{*result.Message})
+}
+
+func inviteToProto(invite *models.ChatInvite) *pb.Invite {
+	result := &pb.Invite{
+		Id:               utils.FormatID(invite.ID),
+		ChatId:           utils.FormatID(invite.ChatID),
+		Code:             invite.Code,
+		CreatorId:        utils.FormatID(invite.CreatorID),
+		CreatedAt:        invite.CreatedAt.UnixMilli(),
+		MaxUses:          int32(invite.MaxUses),
+		Uses:             int32(invite.Uses),
+		RequiresApproval: invite.RequiresApproval,
+	}
+	if invite.ExpiresAt != nil {
+		result.ExpiresAt = invite.ExpiresAt.UnixMilli()
+	}
+	return result
+}
@@ -0,0 +1,153 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// attachMedia checks that the sender can send the uploaded media as content of the given type,
+// an empty prefix accepts every type. Besides their own uploads, users can forward media sent to their chats.
+func (s *ChatServer) attachMedia(senderID uint, rawMediaID string, mimePrefix string) (*models.Media, error) {
+	if rawMediaID == "" {
+		return nil, status.Error(codes.InvalidArgument, "media_id is required")
+	}
+	mediaID, err := utils.ParseID(rawMediaID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	media, err := s.media_repo.GetMedia(mediaID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "media not found")
+	}
+	if err != nil {
+		log.Printf("GetMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load media")
+	}
+
+	allowed, err := s.media_repo.CanAccessMedia(media, senderID)
+	if err != nil {
+		log.Printf("CanAccessMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load media")
+	}
+	if !allowed {
+		return nil, status.Error(codes.NotFound, "media not found")
+	}
+
+	if media.CompletedAt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "upload is not complete")
+	}
+	if err := checkAttachment(media, mimePrefix); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// storeInline stores content sent inline in a message as media of the sender.
+// The type detected from the content must start with the prefix, the upload checks verify it.
+func (s *ChatServer) storeInline(ctx context.Context, senderID uint, content []byte, mimeType string, mimePrefix string) (*models.Media, error) {
+	if s.store_inline == nil {
+		return nil, status.Error(codes.Unimplemented, "inline content is not supported")
+	}
+	if !strings.HasPrefix(mimeType, mimePrefix) {
+		return nil, status.Errorf(codes.InvalidArgument, "content mime type must start with %s", mimePrefix)
+	}
+	media, err := s.store_inline(ctx, senderID, content, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAttachment(media, mimePrefix); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// checkAttachment checks that completed media can be sent as content of the given type
+func checkAttachment(media *models.Media, mimePrefix string) error {
+	// Content still being scanned can be sent, the recipients see it once it is clean
+	if media.ScanStatus == models.ScanInfected {
+		return status.Error(codes.FailedPrecondition, "media is quarantined")
+	}
+	if !strings.HasPrefix(media.MimeType, mimePrefix) {
+		return status.Errorf(codes.InvalidArgument, "media mime type must start with %s", mimePrefix)
+	}
+	return nil
+}
+
+// checkVoice checks that the audio media is a recording of an allowed duration
+func (s *ChatServer) checkVoice(media *models.Media) error {
+	if media.Duration <= 0 {
+		return status.Error(codes.InvalidArgument, "voice message must be Ogg Opus or PCM WAV")
+	}
+	if media.Duration > s.config.MaxVoiceDuration {
+		return status.Errorf(codes.InvalidArgument, "voice message must not exceed %s", s.config.MaxVoiceDuration)
+	}
+	return nil
+}
+
+// PublishScanResult tells the participants of the chats where the scanned content was sent
+// whether they can download it now
+func (s *ChatServer) PublishScanResult(messages []models.Message, scan_status string) {
+	state := attachmentStateToProto(scan_status)
+	for _, message := range messages {
+		event := &pb.ServerEvent{Event: &pb.ServerEvent_Attachment{Attachment: &pb.AttachmentEvent{
+			ChatId:    utils.FormatID(message.ChatID),
+			MessageId: utils.FormatID(message.ID),
+			State:     state,
+		}}}
+		if err := s.publishToChat(context.Background(), message.ChatID, event); err != nil {
+			log.Printf("Attachment event error: %v", err)
+		}
+	}
+}
+
+func attachmentStateToProto(scan_status string) pb.AttachmentState {
+	switch scan_status {
+	case models.ScanPending:
+		return pb.AttachmentState_SCANNING
+	case models.ScanInfected:
+		return pb.AttachmentState_QUARANTINED
+	}
+	return pb.AttachmentState_READY
+}
+
+func attachmentToProto(message *models.Message) *pb.MediaAttachment {
+	result := &pb.MediaAttachment{MediaId: utils.FormatID(*message.MediaID)}
+	if message.Media != nil {
+		result.MimeType = message.Media.MimeType
+		result.Size = message.Media.Size
+		result.Width = int32(message.Media.Width)
+		result.Height = int32(message.Media.Height)
+		for _, thumbnail := range message.Media.Thumbnails {
+			result.Thumbnails = append(result.Thumbnails, &pb.ImageThumbnail{
+				Name:   thumbnail.Name,
+				Width:  int32(thumbnail.Width),
+				Height: int32(thumbnail.Height),
+			})
+		}
+		result.DurationMs = message.Media.Duration.Milliseconds()
+		result.Waveform = message.Media.Waveform
+	}
+	return result
+}
+
+func fileToProto(message *models.Message) *pb.FileAttachment {
+	result := &pb.FileAttachment{
+		MediaId:  utils.FormatID(*message.MediaID),
+		Filename: message.Filename,
+	}
+	if message.Media != nil {
+		result.MimeType = message.Media.MimeType
+		result.Size = message.Media.Size
+	}
+	return result
+}
@@ -0,0 +1,395 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/data"
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// GetParticipants lists the members of a chat with their roles.
+// Subscribers of a channel do not see each other, only channel admins list them.
+func (s *ChatServer) GetParticipants(ctx context.Context, req *pb.GetParticipantsRequest) (*pb.GetParticipantsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.checkRole(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+	chatID := participant.ChatID
+
+	if !isAdminRole(participant.Role) {
+		chatType, err := s.chatType(chatID)
+		if err != nil {
+			return nil, err
+		}
+		if chatType == models.ChatTypeChannel {
+			return nil, status.Error(codes.PermissionDenied, "only channel admins can list subscribers")
+		}
+	}
+
+	participants, err := s.chat_repo.GetParticipants(chatID)
+	if err != nil {
+		log.Printf("GetParticipants error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load participants")
+	}
+
+	response := &pb.GetParticipantsResponse{}
+	for _, participant := range participants {
+		response.Participants = append(response.Participants, &pb.Participant{
+			UserId:   utils.FormatID(participant.UserID),
+			Role:     roleToProto(participant.Role),
+			JoinedAt: participant.JoinedAt.UnixMilli(),
+		})
+	}
+	return response, nil
+}
+
+// AddParticipants adds users to a chat, only admins can do it
+func (s *ChatServer) AddParticipants(ctx context.Context, req *pb.AddParticipantsRequest) (*pb.AddParticipantsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := s.checkRole(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupChat(actor.ChatID); err != nil {
+		return nil, err
+	}
+	if !isAdminRole(actor.Role) {
+		return nil, status.Error(codes.PermissionDenied, "only chat admins can add participants")
+	}
+
+	if len(req.UserIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_ids are required")
+	}
+	userIDs, err := s.parseUserIDs(req.UserIds)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.chat_repo.AddParticipants(actor.ChatID, userID, userIDs)
+	if errors.Is(err, data.ErrNotPermitted) {
+		return nil, status.Error(codes.PermissionDenied, "only chat admins can add participants")
+	}
+	if err != nil {
+		log.Printf("AddParticipants error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to add participants")
+	}
+
+	response := &pb.AddParticipantsResponse{}
+	if message == nil {
+		return response, nil
+	}
+	for _, addedID := range message.System.UserIDs {
+		response.AddedUserIds = append(response.AddedUserIds, utils.FormatID(addedID))
+	}
+
+	if err := s.updateFollowers(ctx, actor.ChatID, message.System.UserIDs, true); err != nil {
+		return nil, err
+	}
+	if err := s.publishSystemMessages(ctx, actor.ChatID, []models.Message{*message}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RemoveParticipant removes a member from a chat. Admins remove members, the owner removes anyone.
+func (s *ChatServer) RemoveParticipant(ctx context.Context, req *pb.RemoveParticipantRequest) (*pb.RemoveParticipantResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, target, err := s.checkManageParticipant(req.ChatId, req.UserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != models.ChatRoleOwner && target.Role != models.ChatRoleMember {
+		return nil, status.Error(codes.PermissionDenied, "only the chat owner can remove admins")
+	}
+
+	message, err := s.chat_repo.RemoveParticipant(actor.ChatID, userID, target.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "user is not a member of the chat")
+	}
+	if errors.Is(err, data.ErrNotPermitted) {
+		return nil, status.Error(codes.PermissionDenied, "only the chat owner can remove admins")
+	}
+	if err != nil {
+		log.Printf("RemoveParticipant error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to remove participant")
+	}
+
+	s.typing.Stop(actor.ChatID, target.UserID)
+	if err := s.updateFollowers(ctx, actor.ChatID, []uint{target.UserID}, false); err != nil {
+		return nil, err
+	}
+	if err := s.publishSystemMessages(ctx, actor.ChatID, []models.Message{*message}, target.UserID); err != nil {
+		return nil, err
+	}
+	return &pb.RemoveParticipantResponse{}, nil
+}
+
+// LeaveChat removes the caller from a chat, ownership of a leaving owner passes to another member
+func (s *ChatServer) LeaveChat(ctx context.Context, req *pb.LeaveChatRequest) (*pb.LeaveChatResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkMembership(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupChat(chatID); err != nil {
+		return nil, err
+	}
+
+	messages, err := s.chat_repo.LeaveChat(chatID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.PermissionDenied, "user is not a member of the chat")
+	}
+	if err != nil {
+		log.Printf("LeaveChat error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to leave chat")
+	}
+
+	s.typing.Stop(chatID, userID)
+	if err := s.updateFollowers(ctx, chatID, []uint{userID}, false); err != nil {
+		return nil, err
+	}
+	if err := s.publishSystemMessages(ctx, chatID, messages, userID); err != nil {
+		return nil, err
+	}
+	return &pb.LeaveChatResponse{}, nil
+}
+
+// TransferOwnership passes the chat to another member, the caller stays as an admin
+func (s *ChatServer) TransferOwnership(ctx context.Context, req *pb.TransferOwnershipRequest) (*pb.TransferOwnershipResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actor, target, err := s.checkManageParticipant(req.ChatId, req.NewOwnerId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != models.ChatRoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "only the chat owner can transfer ownership")
+	}
+
+	message, err := s.chat_repo.TransferOwnership(actor.ChatID, userID, target.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "user is not a member of the chat")
+	}
+	if errors.Is(err, data.ErrNotPermitted) {
+		return nil, status.Error(codes.PermissionDenied, "only the chat owner can transfer ownership")
+	}
+	if err != nil {
+		log.Printf("TransferOwnership error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to transfer ownership")
+	}
+
+	if err := s.publishSystemMessages(ctx, actor.ChatID, []models.Message{*message}); err != nil {
+		return nil, err
+	}
+	return &pb.TransferOwnershipResponse{}, nil
+}
+
+// SetParticipantRole promotes a member to admin or demotes an admin, only the owner can do it
+func (s *ChatServer) SetParticipantRole(ctx context.Context, req *pb.SetParticipantRoleRequest) (*pb.SetParticipantRoleResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var role string
+	switch req.Role {
+	case pb.ChatRole_ADMIN:
+		role = models.ChatRoleAdmin
+	case pb.ChatRole_MEMBER:
+		role = models.ChatRoleMember
+	default:
+		return nil, status.Error(codes.InvalidArgument, "role must be ADMIN or MEMBER, use TransferOwnership to change the owner")
+	}
+
+	actor, target, err := s.checkManageParticipant(req.ChatId, req.UserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != models.ChatRoleOwner {
+		return nil, status.Error(codes.PermissionDenied, "only the chat owner can change roles")
+	}
+
+	message, err := s.chat_repo.SetParticipantRole(actor.ChatID, userID, target.UserID, role)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "user is not a member of the chat")
+	}
+	if errors.Is(err, data.ErrNotPermitted) {
+		return nil, status.Error(codes.PermissionDenied, "only the chat owner can change roles")
+	}
+	if err != nil {
+		log.Printf("SetParticipantRole error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to change role")
+	}
+
+	if message != nil {
+		if err := s.publishSystemMessages(ctx, actor.ChatID, []models.Message{*message}); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.SetParticipantRoleResponse{}, nil
+}
+
+// checkManageParticipant loads the memberships of the caller and of another member the caller wants to manage
+func (s *ChatServer) checkManageParticipant(rawChatID, rawTargetID string, userID uint) (*models.ChatParticipant, *models.ChatParticipant, error) {
+	actor, err := s.checkGroupAdmin(rawChatID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targetID, err := utils.ParseID(rawTargetID)
+	if err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if targetID == userID {
+		return nil, nil, status.Error(codes.InvalidArgument, "users can not manage themselves")
+	}
+
+	target, err := s.chat_repo.GetParticipant(actor.ChatID, targetID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, status.Error(codes.NotFound, "user is not a member of the chat")
+	}
+	if err != nil {
+		log.Printf("GetParticipant error: %v", err)
+		return nil, nil, status.Error(codes.Internal, "failed to check chat membership")
+	}
+	if target.Role == models.ChatRoleOwner {
+		return nil, nil, status.Error(codes.PermissionDenied, "the chat owner can not be managed")
+	}
+	return actor, target, nil
+}
+
+// checkGroupAdmin makes sure the user administers the group chat
+func (s *ChatServer) checkGroupAdmin(rawChatID string, userID uint) (*models.ChatParticipant, error) {
+	participant, err := s.checkRole(rawChatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupChat(participant.ChatID); err != nil {
+		return nil, err
+	}
+	if !isAdminRole(participant.Role) {
+		return nil, status.Error(codes.PermissionDenied, "only chat admins can manage participants")
+	}
+	return participant, nil
+}
+
+// checkGroupChat rejects membership changes of direct chats, they always connect the same two users
+func (s *ChatServer) checkGroupChat(chatID uint) error {
+	chat, err := s.chat_repo.GetChatByID(chatID)
+	if err != nil {
+		log.Printf("GetChatByID error: %v", err)
+		return status.Error(codes.Internal, "failed to load chat")
+	}
+	if chat.Type == models.ChatTypeDirect {
+		return status.Error(codes.FailedPrecondition, "participants of a direct chat can not change")
+	}
+	return nil
+}
+
+// publishSystemMessages delivers system messages to the chat participants and to former members
+// who must learn that they left
+func (s *ChatServer) publishSystemMessages(ctx context.Context, chatID uint, messages []models.Message, former ...uint) error {
+	for i := range messages {
+		event := &pb.ServerEvent{Event: &pb.ServerEvent_Message{Message: messageToProto(&messages[i])}}
+		if err := s.publishToChat(ctx, chatID, event); err != nil {
+			return err
+		}
+		if len(former) == 0 {
+			continue
+		}
+		if err := s.publish(ctx, former, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func roleToProto(role string) pb.ChatRole {
+	switch role {
+	case models.ChatRoleOwner:
+		return pb.ChatRole_OWNER
+	case models.ChatRoleAdmin:
+		return pb.ChatRole_ADMIN
+	default:
+		return pb.ChatRole_MEMBER
+	}
+}
+
+func systemEventToProto(payload *models.SystemPayload) *pb.SystemEvent {
+	result := &pb.SystemEvent{}
+	if payload == nil {
+		return result
+	}
+
+	switch payload.Action {
+	case models.SystemActionMembersAdded:
+		result.Type = pb.SystemEvent_MEMBERS_ADDED
+	case models.SystemActionMemberRemoved:
+		result.Type = pb.SystemEvent_MEMBER_REMOVED
+	case models.SystemActionMemberLeft:
+		result.Type = pb.SystemEvent_MEMBER_LEFT
+	case models.SystemActionOwnershipTransferred:
+		result.Type = pb.SystemEvent_OWNERSHIP_TRANSFERRED
+	case models.SystemActionRoleChanged:
+		result.Type = pb.SystemEvent_ROLE_CHANGED
+	case models.SystemActionMemberJoined:
+		result.Type = pb.SystemEvent_MEMBER_JOINED
+	}
+	for _, userID := range payload.UserIDs {
+		result.UserIds = append(result.UserIds, utils.FormatID(userID))
+	}
+	if payload.Role != "" {
+		result.Role = roleToProto(payload.Role)
+	}
+	return result
+}
+
+// systemPreview describes a system message in the chat list
+func systemPreview(payload *models.SystemPayload) string {
+	if payload == nil {
+		return "[System message]"
+	}
+
+	switch payload.Action {
+	case models.SystemActionMembersAdded:
+		return "[Members added]"
+	case models.SystemActionMemberRemoved:
+		return "[Member removed]"
+	case models.SystemActionMemberLeft:
+		return "[Member left]"
+	case models.SystemActionOwnershipTransferred:
+		return "[Ownership transferred]"
+	case models.SystemActionRoleChanged:
+		return "[Role changed]"
+	case models.SystemActionMemberJoined:
+		return "[Member joined]"
+	}
+	return "[System message]"
+}
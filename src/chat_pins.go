@@ -0,0 +1,147 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/data"
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PinMessage pins a message to the top of the chat
+func (s *ChatServer) PinMessage(ctx context.Context, req *pb.PinMessageRequest) (*pb.PinMessageResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkPinPermission(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.findMessage(chatID, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	if message.DeletedAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "message is deleted")
+	}
+
+	pinned, err := s.chat_repo.PinMessage(chatID, message.ID, userID, s.config.MaxPins)
+	if errors.Is(err, data.ErrTooManyPins) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		log.Printf("PinMessage error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to pin message")
+	}
+
+	if pinned {
+		if err := s.publishPin(ctx, chatID, message.ID, userID, false); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.PinMessageResponse{}, nil
+}
+
+// UnpinMessage removes a message from the pins of the chat
+func (s *ChatServer) UnpinMessage(ctx context.Context, req *pb.UnpinMessageRequest) (*pb.UnpinMessageResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkPinPermission(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.findMessage(chatID, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	unpinned, err := s.chat_repo.UnpinMessage(chatID, message.ID)
+	if err != nil {
+		log.Printf("UnpinMessage error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to unpin message")
+	}
+
+	if unpinned {
+		if err := s.publishPin(ctx, chatID, message.ID, userID, true); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.UnpinMessageResponse{}, nil
+}
+
+// ListPinnedMessages returns the pinned messages of the chat, most recently pinned first
+func (s *ChatServer) ListPinnedMessages(ctx context.Context, req *pb.ListPinnedMessagesRequest) (*pb.ListPinnedMessagesResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkMembership(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pins, err := s.chat_repo.GetPinnedMessages(chatID, userID)
+	if err != nil {
+		log.Printf("GetPinnedMessages error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load pinned messages")
+	}
+
+	messages := make([]models.Message, 0, len(pins))
+	for _, pin := range pins {
+		messages = append(messages, *pin.Message)
+	}
+	converted, err := s.messagesToProto(messages, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*pb.PinnedMessage, len(pins))
+	for i, pin := range pins {
+		result[i] = &pb.PinnedMessage{
+			Message:  converted[i],
+			PinnedBy: utils.FormatID(pin.PinnedBy),
+			PinnedAt: pin.PinnedAt.UnixMilli(),
+		}
+	}
+	return &pb.ListPinnedMessagesResponse{Pins: result}, nil
+}
+
+// checkPinPermission allows either participant of a direct chat and admins of other chats to manage pins
+func (s *ChatServer) checkPinPermission(rawChatID string, userID uint) (uint, error) {
+	participant, err := s.checkRole(rawChatID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	chatType, err := s.chatType(participant.ChatID)
+	if err != nil {
+		return 0, err
+	}
+	if chatType != models.ChatTypeDirect && !isAdminRole(participant.Role) {
+		return 0, status.Error(codes.PermissionDenied, "only chat admins can pin messages")
+	}
+	return participant.ChatID, nil
+}
+
+func (s *ChatServer) publishPin(ctx context.Context, chatID, messageID, userID uint, unpinned bool) error {
+	event := &pb.ServerEvent{Event: &pb.ServerEvent_Pin{Pin: &pb.PinEvent{
+		ChatId:    utils.FormatID(chatID),
+		MessageId: utils.FormatID(messageID),
+		UserId:    utils.FormatID(userID),
+		Unpinned:  unpinned,
+	}}}
+	return s.publishToChat(ctx, chatID, event)
+}
@@ -0,0 +1,154 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"log"
+	"time"
+)
+
+// maxLinkPreviews is the number of links of a message that get a preview
+const maxLinkPreviews = 3
+
+// unfurlLinks fetches the previews of the links in a text message in the background
+// and sends them to the chat participants
+func (s *ChatServer) unfurlLinks(message *models.Message) {
+	if s.previews == nil || message.Kind != models.MessageKindText {
+		return
+	}
+	urls := utils.ExtractURLs(message.Text, maxLinkPreviews)
+	if len(urls) == 0 {
+		return
+	}
+
+	chatID, messageID, text := message.ChatID, message.ID, message.Text
+	go func() {
+		previews, err := s.loadLinkPreviews(urls)
+		if err != nil {
+			log.Printf("GetLinkPreviews error: %v", err)
+			return
+		}
+		if len(previews) == 0 {
+			return
+		}
+
+		// The previews of a text edited or deleted in the meantime are outdated
+		current, err := s.chat_repo.GetMessageByID(chatID, messageID)
+		if err != nil || current.DeletedAt != nil || current.Text != text {
+			return
+		}
+
+		event := &pb.ServerEvent{Event: &pb.ServerEvent_LinkPreview{LinkPreview: &pb.LinkPreviewEvent{
+			ChatId:    utils.FormatID(chatID),
+			MessageId: utils.FormatID(messageID),
+			Previews:  linkPreviewsToProto(previews),
+		}}}
+		if err := s.publishToChat(context.Background(), chatID, event); err != nil {
+			log.Printf("Link preview event error: %v", err)
+		}
+	}()
+}
+
+// loadLinkPreviews returns the previews of the links that have one, fetching links not cached or expired
+func (s *ChatServer) loadLinkPreviews(urls []string) ([]models.LinkPreview, error) {
+	cached, err := s.chat_repo.GetLinkPreviews(urls)
+	if err != nil {
+		return nil, err
+	}
+	byURL := make(map[string]models.LinkPreview, len(cached))
+	for _, preview := range cached {
+		byURL[preview.URL] = preview
+	}
+
+	var result []models.LinkPreview
+	for _, link := range urls {
+		preview, ok := byURL[link]
+		if !ok || time.Since(preview.FetchedAt) > s.config.LinkPreviewTTL {
+			preview = s.fetchLinkPreview(link)
+		}
+		if !preview.Empty() {
+			result = append(result, preview)
+		}
+	}
+	return result, nil
+}
+
+// fetchLinkPreview fetches the link and caches the result.
+// Links that cannot be fetched, such as dead or internal ones, are cached without details.
+func (s *ChatServer) fetchLinkPreview(link string) models.LinkPreview {
+	result, _, _ := s.unfurling.Do(link, func() (any, error) {
+		preview := models.LinkPreview{URL: link, FetchedAt: time.Now()}
+		fetched, err := s.previews.Fetch(context.Background(), link)
+		if err == nil {
+			preview.Title = fetched.Title
+			preview.Description = fetched.Description
+			preview.ImageURL = fetched.ImageURL
+		}
+
+		if err := s.chat_repo.SaveLinkPreview(&preview); err != nil {
+			log.Printf("SaveLinkPreview error: %v", err)
+		}
+		return preview, nil
+	})
+	return result.(models.LinkPreview)
+}
+
+// cachedLinkPreviews returns the cached previews of the links in text messages by message id,
+// links that were not fetched yet have none
+func (s *ChatServer) cachedLinkPreviews(messages []models.Message) (map[uint][]*pb.LinkPreview, error) {
+	if s.previews == nil {
+		return nil, nil
+	}
+
+	links := make(map[uint][]string)
+	var urls []string
+	for _, message := range messages {
+		if message.Kind != models.MessageKindText || message.DeletedAt != nil {
+			continue
+		}
+		found := utils.ExtractURLs(message.Text, maxLinkPreviews)
+		links[message.ID] = found
+		urls = append(urls, found...)
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	cached, err := s.chat_repo.GetLinkPreviews(urls)
+	if err != nil {
+		return nil, err
+	}
+	byURL := make(map[string]models.LinkPreview, len(cached))
+	for _, preview := range cached {
+		byURL[preview.URL] = preview
+	}
+
+	result := make(map[uint][]*pb.LinkPreview)
+	for messageID, found := range links {
+		var previews []models.LinkPreview
+		for _, link := range found {
+			if preview, ok := byURL[link]; ok && !preview.Empty() {
+				previews = append(previews, preview)
+			}
+		}
+		if len(previews) > 0 {
+			result[messageID] = linkPreviewsToProto(previews)
+		}
+	}
+	return result, nil
+}
+
+func linkPreviewsToProto(previews []models.LinkPreview) []*pb.LinkPreview {
+	result := make([]*pb.LinkPreview, len(previews))
+	for i, preview := range previews {
+		result[i] = &pb.LinkPreview{
+			Url:         preview.URL,
+			Title:       preview.Title,
+			Description: preview.Description,
+			ImageUrl:    preview.ImageURL,
+		}
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/data"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AddReaction adds the caller's emoji reaction to a message
+func (s *ChatServer) AddReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.ReactionResponse, error) {
+	return s.reactionRPC(ctx, req, false)
+}
+
+// RemoveReaction removes the caller's emoji reaction from a message
+func (s *ChatServer) RemoveReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.ReactionResponse, error) {
+	return s.reactionRPC(ctx, req, true)
+}
+
+func (s *ChatServer) reactionRPC(ctx context.Context, req *pb.ReactionRequest, remove bool) (*pb.ReactionResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messageID, err := s.react(ctx, userID, req.ChatId, req.MessageId, req.Emoji, remove)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.chat_repo.GetReactionSummaries([]uint{messageID}, userID)
+	if err != nil {
+		log.Printf("GetReactionSummaries error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load reactions")
+	}
+	return &pb.ReactionResponse{Reactions: reactionsToProto(summaries[messageID])}, nil
+}
+
+// react applies the reaction change and notifies the participants if anything changed
+func (s *ChatServer) react(ctx context.Context, userID uint, rawChatID, rawMessageID, emoji string, remove bool) (uint, error) {
+	chatID, err := s.checkMembership(rawChatID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := utils.ValidateEmoji(emoji); err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	message, err := s.findMessage(chatID, rawMessageID)
+	if err != nil {
+		return 0, err
+	}
+
+	var changed bool
+	if remove {
+		changed, err = s.chat_repo.RemoveReaction(message.ID, userID, emoji)
+		if err != nil {
+			log.Printf("RemoveReaction error: %v", err)
+			return 0, status.Error(codes.Internal, "failed to remove reaction")
+		}
+	} else {
+		if message.DeletedAt != nil {
+			return 0, status.Error(codes.FailedPrecondition, "message is deleted")
+		}
+
+		changed, err = s.chat_repo.AddReaction(message.ID, userID, emoji, s.config.MaxReactions)
+		if errors.Is(err, data.ErrTooManyReactions) {
+			return 0, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if err != nil {
+			log.Printf("AddReaction error: %v", err)
+			return 0, status.Error(codes.Internal, "failed to add reaction")
+		}
+	}
+
+	if !changed {
+		return message.ID, nil
+	}
+
+	event := &pb.ServerEvent{Event: &pb.ServerEvent_Reaction{Reaction: &pb.ReactionEvent{
+		ChatId:    utils.FormatID(chatID),
+		MessageId: utils.FormatID(message.ID),
+		UserId:    utils.FormatID(userID),
+		Emoji:     emoji,
+		Removed:   remove,
+	}}}
+	if err := s.publishToChat(ctx, chatID, event); err != nil {
+		return 0, err
+	}
+	return message.ID, nil
+}
+
+func reactionsToProto(summaries []data.ReactionSummary) []*pb.ReactionSummary {
+	var result []*pb.ReactionSummary
+	for _, summary := range summaries {
+		result = append(result, &pb.ReactionSummary{
+			Emoji:       summary.Emoji,
+			Count:       int32(summary.Count),
+			ReactedByMe: summary.ReactedByMe,
+		})
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// MarkDelivered acknowledges delivery of every chat message up to the given one
+func (s *ChatServer) MarkDelivered(ctx context.Context, req *pb.MarkDeliveredRequest) (*pb.ReceiptsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.markReceipts(ctx, userID, req.ChatId, req.UpToMessageId, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReceiptsResponse{UpdatedCount: int32(updated)}, nil
+}
+
+// MarkRead marks every chat message up to the given one as read
+func (s *ChatServer) MarkRead(ctx context.Context, req *pb.MarkReadRequest) (*pb.ReceiptsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.markReceipts(ctx, userID, req.ChatId, req.UpToMessageId, true)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReceiptsResponse{UpdatedCount: int32(updated)}, nil
+}
+
+// GetMessageReceipts returns who received and read a message, only the sender may see it
+func (s *ChatServer) GetMessageReceipts(ctx context.Context, req *pb.GetMessageReceiptsRequest) (*pb.GetMessageReceiptsResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkMembership(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.findMessage(chatID, req.MessageId)
+	if err != nil {
+		return nil, err
+	}
+	if message.SenderID != userID {
+		return nil, status.Error(codes.PermissionDenied, "only the sender can see message receipts")
+	}
+
+	receipts, err := s.chat_repo.GetMessageReceipts(message.ID)
+	if err != nil {
+		log.Printf("GetMessageReceipts error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load receipts")
+	}
+
+	response := &pb.GetMessageReceiptsResponse{}
+	for _, receipt := range receipts {
+		result := &pb.MessageReceipt{UserId: utils.FormatID(receipt.UserID)}
+		if receipt.DeliveredAt != nil {
+			result.DeliveredAt = receipt.DeliveredAt.UnixMilli()
+		}
+		if receipt.ReadAt != nil {
+			result.ReadAt = receipt.ReadAt.UnixMilli()
+		}
+		response.Receipts = append(response.Receipts, result)
+	}
+	return response, nil
+}
+
+// markReceipts records receipts of the user up to the message and returns the number of messages
+// whose status changed
+func (s *ChatServer) markReceipts(ctx context.Context, userID uint, rawChatID, rawMessageID string, read bool) (int, error) {
+	chatID, err := s.checkMembership(rawChatID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	upTo, err := utils.ParseID(rawMessageID)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var changed []models.Message
+	if read {
+		changed, err = s.chat_repo.MarkRead(chatID, userID, upTo)
+	} else {
+		changed, err = s.chat_repo.MarkDelivered(chatID, userID, upTo)
+	}
+	if err != nil {
+		log.Printf("Mark receipts error: %v", err)
+		return 0, status.Error(codes.Internal, "failed to update receipts")
+	}
+
+	// Push one watermark per sender and status instead of an event per message,
+	// so acknowledging a long backlog stays a few events
+	type watermark struct {
+		senderID uint
+		status   int32
+	}
+	latest := make(map[watermark]uint)
+	var order []watermark
+	for i := range changed {
+		key := watermark{senderID: changed[i].SenderID, status: changed[i].Status}
+		if _, ok := latest[key]; !ok {
+			order = append(order, key)
+		}
+		latest[key] = max(latest[key], changed[i].ID)
+	}
+	// RECEIVED goes out before READ, so a client applying them in order ends with the higher status
+	sort.SliceStable(order, func(i, j int) bool { return order[i].status < order[j].status })
+
+	now := time.Now().UnixMilli()
+	for _, key := range order {
+		event := &pb.ServerEvent{Event: &pb.ServerEvent_Receipt{Receipt: &pb.ReceiptEvent{
+			ChatId:    utils.FormatID(chatID),
+			MessageId: utils.FormatID(latest[key]),
+			Status:    pb.ChatMessageStatus(key.status),
+			UserId:    utils.FormatID(userID),
+			Timestamp: now,
+		}}}
+		if err := s.publish(ctx, []uint{key.senderID}, event); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(changed), nil
+}
+
+// findMessage parses the message id and loads the message of the chat
+func (s *ChatServer) findMessage(chatID uint, rawMessageID string) (*models.Message, error) {
+	messageID, err := utils.ParseID(rawMessageID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	message, err := s.chat_repo.GetMessageByID(chatID, messageID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "message not found")
+	}
+	if err != nil {
+		log.Printf("GetMessageByID error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load message")
+	}
+	return message, nil
+}
@@ -0,0 +1,89 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/data"
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SearchMessages finds messages by their text in the chats of the caller, newest first
+func (s *ChatServer) SearchMessages(ctx context.Context, req *pb.SearchMessagesRequest) (*pb.SearchMessagesResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateSearchQuery(req.Query); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var filter data.SearchFilter
+	if req.ChatId != "" {
+		filter.ChatID, err = s.checkMembership(req.ChatId, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if req.SenderId != "" {
+		filter.SenderID, err = utils.ParseID(req.SenderId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	if req.After < 0 || req.Before < 0 {
+		return nil, status.Error(codes.InvalidArgument, "date range must not be negative")
+	}
+	if req.After > 0 {
+		filter.After = time.UnixMilli(req.After)
+	}
+	if req.Before > 0 {
+		filter.Before = time.UnixMilli(req.Before)
+	}
+	if req.After > 0 && req.Before > 0 && req.Before <= req.After {
+		return nil, status.Error(codes.InvalidArgument, "before must be later than after")
+	}
+
+	var cursor *data.MessageCursor
+	if req.Cursor != "" {
+		decoded, err := data.DecodeMessageCursor(req.Cursor)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		cursor = &decoded
+	}
+
+	results, hasMore, err := s.chat_repo.SearchMessages(userID, req.Query, filter, cursor, int(req.Count))
+	if err != nil {
+		log.Printf("SearchMessages error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to search messages")
+	}
+
+	messages := make([]models.Message, len(results))
+	for i := range results {
+		messages[i] = results[i].Message
+	}
+	converted, err := s.messagesToProto(messages, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.SearchMessagesResponse{
+		Results:    make([]*pb.SearchResult, len(results)),
+		HasMore:    hasMore,
+		NextCursor: req.Cursor,
+	}
+	for i := range results {
+		response.Results[i] = &pb.SearchResult{Message: converted[i], Snippet: results[i].Snippet}
+	}
+	if len(results) > 0 {
+		response.NextCursor = data.CursorOf(&messages[len(messages)-1]).Encode()
+	}
+	return response, nil
+}
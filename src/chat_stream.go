@@ -0,0 +1,193 @@
+package alexchatapp
+
+import (
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"io"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// repliesBufferSize is the number of Ack and Error replies queued for one stream
+const repliesBufferSize = 16
+
+// ChatStream receives client events and sends every event addressed to the user,
+// together with Ack and Error replies to the client's own events
+func (s *ChatServer) ChatStream(stream pb.ChatService_ChatStreamServer) error {
+	userID, err := authenticatedUserID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	subscriber := s.chat_hub.Subscribe(userID)
+	defer func() {
+		s.chat_hub.Unsubscribe(subscriber)
+		// Typing indicators of a user without open streams would hang until they expire
+		if !s.chat_hub.IsOnline(userID) {
+			s.typing.StopAll(userID)
+		}
+	}()
+
+	// Subscriptions made from now on reach the stream through follow events,
+	// so loading the channels after subscribing misses none of them
+	channelIDs, err := s.chat_repo.GetChannelIDsByUser(userID)
+	if err != nil {
+		log.Printf("GetChannelIDsByUser error: %v", err)
+		return status.Error(codes.Internal, "failed to load channels")
+	}
+	s.chat_hub.FollowChannels(subscriber, channelIDs)
+
+	// An unfollow event handled before FollowChannels found nothing to remove,
+	// channels left in the meantime are dropped after loading them again
+	current, err := s.chat_repo.GetChannelIDsByUser(userID)
+	if err != nil {
+		log.Printf("GetChannelIDsByUser error: %v", err)
+		return status.Error(codes.Internal, "failed to load channels")
+	}
+	s.chat_hub.UnfollowChannels(subscriber, leftChannels(channelIDs, current))
+
+	// Receiving runs in its own goroutine, sending stays in this one
+	// because grpc streams do not allow concurrent Send calls
+	replies := make(chan *pb.ServerEvent, repliesBufferSize)
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- s.receiveEvents(stream, userID, replies)
+	}()
+
+	for {
+		select {
+		case event := <-subscriber.Events():
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case reply := <-replies:
+			if err := stream.Send(reply); err != nil {
+				return err
+			}
+		case <-subscriber.Done():
+			return status.Error(codes.ResourceExhausted, "stream is too slow to keep up, reconnect and reload history")
+		case err := <-recvErr:
+			return err
+		case <-stream.Context().Done():
+			// Either the client went away or the token expired
+			return context.Cause(stream.Context())
+		}
+	}
+}
+
+// receiveEvents handles incoming events until the client closes the stream
+func (s *ChatServer) receiveEvents(stream pb.ChatService_ChatStreamServer, userID uint, replies chan<- *pb.ServerEvent) error {
+	ctx := stream.Context()
+
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		reply := s.handleClientEvent(ctx, userID, in)
+		if reply == nil {
+			continue
+		}
+
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleClientEvent applies the event and returns the reply for the client.
+// Errors are always reported, successful events are acknowledged only when they carry a correlation id.
+func (s *ChatServer) handleClientEvent(ctx context.Context, userID uint, in *pb.ClientEvent) *pb.ServerEvent {
+	var (
+		messageID string
+		err       error
+	)
+
+	switch event := in.Event.(type) {
+	case *pb.ClientEvent_Message:
+		message, sendErr := s.sendMessage(ctx, userID, event.Message)
+		if sendErr == nil {
+			messageID = utils.FormatID(message.ID)
+		}
+		err = sendErr
+
+	case *pb.ClientEvent_Receipt:
+		receipt := event.Receipt
+		switch receipt.Status {
+		case pb.ChatMessage_RECEIVED:
+			_, err = s.markReceipts(ctx, userID, receipt.ChatId, receipt.MessageId, false)
+		case pb.ChatMessage_READ:
+			_, err = s.markReceipts(ctx, userID, receipt.ChatId, receipt.MessageId, true)
+		default:
+			err = status.Error(codes.InvalidArgument, "receipt status must be RECEIVED or READ")
+		}
+		messageID = receipt.MessageId
+
+	case *pb.ClientEvent_Edit:
+		_, err = s.editMessage(ctx, userID, event.Edit.ChatId, event.Edit.MessageId, event.Edit.Text)
+		messageID = event.Edit.MessageId
+
+	case *pb.ClientEvent_Delete:
+		err = s.deleteMessage(ctx, userID, event.Delete.ChatId, event.Delete.MessageId, event.Delete.ForEveryone)
+		messageID = event.Delete.MessageId
+
+	case *pb.ClientEvent_Typing:
+		err = s.handleTyping(userID, event.Typing)
+
+	case *pb.ClientEvent_Reaction:
+		reaction := event.Reaction
+		_, err = s.react(ctx, userID, reaction.ChatId, reaction.MessageId, reaction.Emoji, reaction.Removed)
+		messageID = reaction.MessageId
+
+	default:
+		err = status.Error(codes.InvalidArgument, "event is required")
+	}
+
+	if err != nil {
+		return errorEvent(in.CorrelationId, err)
+	}
+	if in.CorrelationId == "" {
+		return nil
+	}
+	return &pb.ServerEvent{
+		CorrelationId: in.CorrelationId,
+		Event:         &pb.ServerEvent_Ack{Ack: &pb.Ack{MessageId: messageID}},
+	}
+}
+
+// errorEvent converts a handler error into an Error reply
+func errorEvent(correlationID string, err error) *pb.ServerEvent {
+	st := status.Convert(err)
+	return &pb.ServerEvent{
+		CorrelationId: correlationID,
+		Event: &pb.ServerEvent_Error{Error: &pb.ErrorEvent{
+			Code:    int32(st.Code()),
+			Message: st.Message(),
+		}},
+	}
+}
+
+// leftChannels returns the channels of before that are missing from current
+func leftChannels(before, current []uint) []uint {
+	still := make(map[uint]bool, len(current))
+	for _, channelID := range current {
+		still[channelID] = true
+	}
+
+	var left []uint
+	for _, channelID := range before {
+		if !still[channelID] {
+			left = append(left, channelID)
+		}
+	}
+	return left
+}
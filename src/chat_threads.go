@@ -0,0 +1,106 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/data"
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetThread returns the root message of a thread with a page of its replies
+func (s *ChatServer) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.GetThreadResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkMembership(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := s.findThreadRoot(chatID, req.RootMessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor *data.MessageCursor
+	if req.Cursor != "" {
+		decoded, err := data.DecodeMessageCursor(req.Cursor)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		cursor = &decoded
+	}
+
+	replies, hasMore, err := s.chat_repo.GetThreadPage(root.ID, userID, cursor, int(req.Count))
+	if err != nil {
+		log.Printf("GetThreadPage error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load thread")
+	}
+
+	// The root goes through the same conversion to get its reactions and unread counter
+	converted, err := s.messagesToProto(append([]models.Message{*root}, replies...), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.GetThreadResponse{
+		Root:        converted[0],
+		Replies:     converted[1:],
+		HasMore:     hasMore,
+		UnreadCount: converted[0].ThreadUnreadCount,
+		NextCursor:  req.Cursor,
+	}
+	if len(replies) > 0 {
+		response.NextCursor = data.CursorOf(&replies[len(replies)-1]).Encode()
+	}
+	return response, nil
+}
+
+// MarkThreadRead marks replies of a thread as read up to a message, the caller starts following the thread
+func (s *ChatServer) MarkThreadRead(ctx context.Context, req *pb.MarkThreadReadRequest) (*pb.MarkThreadReadResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chatID, err := s.checkMembership(req.ChatId, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := s.findThreadRoot(chatID, req.RootMessageId)
+	if err != nil {
+		return nil, err
+	}
+
+	upTo, err := utils.ParseID(req.UpToMessageId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	unread, err := s.chat_repo.MarkThreadRead(root.ID, userID, upTo)
+	if err != nil {
+		log.Printf("MarkThreadRead error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to mark thread as read")
+	}
+	return &pb.MarkThreadReadResponse{UnreadCount: int32(unread)}, nil
+}
+
+// findThreadRoot loads the root of the thread the message belongs to, the message itself if it is not a reply
+func (s *ChatServer) findThreadRoot(chatID uint, rawMessageID string) (*models.Message, error) {
+	message, err := s.findMessage(chatID, rawMessageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.ThreadRootID == nil {
+		return message, nil
+	}
+	return s.findMessage(chatID, utils.FormatID(*message.ThreadRootID))
+}
@@ -0,0 +1,68 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/chat"
+	"alexchatapp/src/utils"
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handleTyping starts or stops the typing indicator of the user in a chat
+func (s *ChatServer) handleTyping(userID uint, event *pb.TypingEvent) error {
+	if !s.typing.Allow(userID) {
+		return status.Error(codes.ResourceExhausted, "too many typing events")
+	}
+
+	chatID, err := s.checkMembership(event.ChatId, userID)
+	if err != nil {
+		return err
+	}
+
+	// Nobody watches admins typing a post, and subscribers never post
+	chatType, err := s.chatType(chatID)
+	if err != nil {
+		return err
+	}
+	if chatType == models.ChatTypeChannel {
+		return status.Error(codes.FailedPrecondition, "typing is not shown in channels")
+	}
+
+	if event.Typing {
+		s.typing.Start(chatID, userID)
+	} else {
+		s.typing.Stop(chatID, userID)
+	}
+	return nil
+}
+
+// notifyTyping sends a typing state change to the other participants of the chat, it is never persisted
+func (s *ChatServer) notifyTyping(chatID, userID uint, isTyping bool) {
+	participants, err := s.chat_repo.GetParticipantIDs(chatID)
+	if err != nil {
+		log.Printf("GetParticipantIDs error: %v", err)
+		return
+	}
+
+	recipients := make([]uint, 0, len(participants))
+	for _, participantID := range participants {
+		if participantID != userID {
+			recipients = append(recipients, participantID)
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	event := &pb.ServerEvent{Event: &pb.ServerEvent_Typing{Typing: &pb.TypingEvent{
+		ChatId: utils.FormatID(chatID),
+		UserId: utils.FormatID(userID),
+		Typing: isTyping,
+	}}}
+	if err := s.publish(context.Background(), recipients, event); err != nil {
+		log.Printf("Typing notification error: %v", err)
+	}
+}
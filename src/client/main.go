@@ -9,4 +9,6 @@ func main() {
 	test.AuthClientExample()
 	fmt.Print("\nNEW EXAMPLE\n")
 	test.ProfileClientExample()
+	fmt.Print("\nNEW EXAMPLE\n")
+	test.ChatClientExample()
 }
@@ -0,0 +1,182 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/blob"
+	"alexchatapp/src/filetype"
+	"alexchatapp/src/linkpreview"
+	"alexchatapp/src/scan"
+	"alexchatapp/src/typing"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ChatConfig contains tunable limits of the chat service
+type ChatConfig struct {
+	// EditWindow is how long after sending a message can be edited or deleted for everyone by its sender
+	EditWindow time.Duration
+	// TypingTimeout is how long a typing indicator stays on if the client does not stop it
+	TypingTimeout time.Duration
+	// MaxReactions is the number of different emoji a single message can collect
+	MaxReactions int
+	// MaxPins is the number of messages that can be pinned in one chat
+	MaxPins int
+	// MaxVoiceDuration is the longest recording that can be sent as a voice message
+	MaxVoiceDuration time.Duration
+
+	// LinkPreviews enables previews of links in text messages, fetched within the limits of LinkPreview
+	LinkPreviews bool
+	LinkPreview  linkpreview.Config
+	// LinkPreviewTTL is how long a fetched preview is reused before the link is fetched again
+	LinkPreviewTTL time.Duration
+}
+
+// LoadChatConfig reads chat settings from environment, falling back to defaults
+func LoadChatConfig() ChatConfig {
+	return ChatConfig{
+		EditWindow:       envDuration("CHAT_EDIT_WINDOW", 48*time.Hour),
+		TypingTimeout:    envDuration("CHAT_TYPING_TIMEOUT", typing.DefaultTimeout),
+		MaxReactions:     envInt("CHAT_MAX_REACTIONS", 20),
+		MaxPins:          envInt("CHAT_MAX_PINS", 50),
+		MaxVoiceDuration: envDuration("CHAT_MAX_VOICE_DURATION", 15*time.Minute),
+		LinkPreviews:     envBool("CHAT_LINK_PREVIEWS", true),
+		LinkPreview: linkpreview.Config{
+			Timeout: envDuration("CHAT_LINK_PREVIEW_TIMEOUT", 5*time.Second),
+			MaxSize: int64(envInt("CHAT_LINK_PREVIEW_MAX_SIZE", 512<<10)),
+		},
+		LinkPreviewTTL: envDuration("CHAT_LINK_PREVIEW_TTL", 24*time.Hour),
+	}
+}
+
+// MediaConfig contains storage settings and limits of the media service
+type MediaConfig struct {
+	// Dir keeps unfinished uploads, and stored media when the local store is used
+	Dir string
+	// MaxSize is the largest accepted upload in bytes
+	MaxSize int64
+	// MaxImageSize and MaxVoiceSize limit the images and voice recordings processed in memory
+	MaxImageSize int64
+	MaxVoiceSize int64
+	// Store selects where completed uploads are kept
+	Store blob.Config
+	// GCInterval is how often unused media is collected
+	GCInterval time.Duration
+	// GCGrace is how long uploads may stay unfinished or unsent before they are collected
+	GCGrace time.Duration
+	// Types decides which declared and sniffed types can be uploaded
+	Types filetype.Policy
+	// UserQuota is the total size of completed media a user can own, 0 means no quota
+	UserQuota int64
+	// Scanner checks completed uploads for malware, ScanInterval is how often unscanned content is retried
+	Scanner      scan.Config
+	ScanInterval time.Duration
+}
+
+// defaultDeniedTypes keeps executables out of chats
+const defaultDeniedTypes = "application/vnd.microsoft.portable-executable,application/x-executable,application/x-mach-binary"
+
+// LoadMediaConfig reads media settings from environment, falling back to defaults
+func LoadMediaConfig() MediaConfig {
+	dir := envString("MEDIA_DIR", "media")
+	maxSize := int64(envInt("MEDIA_MAX_SIZE", 100<<20))
+	// An empty deny list is a valid setting
+	deniedTypes, ok := os.LookupEnv("MEDIA_DENIED_TYPES")
+	if !ok {
+		deniedTypes = defaultDeniedTypes
+	}
+
+	return MediaConfig{
+		Dir:          dir,
+		MaxSize:      maxSize,
+		MaxImageSize: int64(envInt("MEDIA_MAX_IMAGE_SIZE", 20<<20)),
+		MaxVoiceSize: int64(envInt("MEDIA_MAX_VOICE_SIZE", 25<<20)),
+		Store: blob.Config{
+			Backend: envString("MEDIA_STORE", "local"),
+			Dir:     filepath.Join(dir, "blobs"),
+			S3: blob.S3Config{
+				Endpoint:  os.Getenv("MEDIA_S3_ENDPOINT"),
+				Bucket:    os.Getenv("MEDIA_S3_BUCKET"),
+				AccessKey: os.Getenv("MEDIA_S3_ACCESS_KEY"),
+				SecretKey: os.Getenv("MEDIA_S3_SECRET_KEY"),
+				Region:    os.Getenv("MEDIA_S3_REGION"),
+				UseSSL:    envBool("MEDIA_S3_USE_SSL", true),
+			},
+			MaxObjectSize: maxSize,
+		},
+		GCInterval: envDuration("MEDIA_GC_INTERVAL", time.Hour),
+		GCGrace:    envDuration("MEDIA_GC_GRACE", 24*time.Hour),
+		Types: filetype.Policy{
+			Allowed: filetype.ParsePatterns(os.Getenv("MEDIA_ALLOWED_TYPES")),
+			Denied:  filetype.ParsePatterns(deniedTypes),
+		},
+		UserQuota: envInt64("MEDIA_USER_QUOTA", 1<<30),
+		Scanner: scan.Config{
+			Backend: envString("MEDIA_SCANNER", "none"),
+			Address: envString("MEDIA_CLAMAV_ADDRESS", "localhost:3310"),
+			Timeout: envDuration("MEDIA_SCAN_TIMEOUT", 5*time.Minute),
+		},
+		ScanInterval: envDuration("MEDIA_SCAN_INTERVAL", time.Minute),
+	}
+}
+
+func envString(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %v", key, err)
+	}
+	return duration
+}
+
+func envBool(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %q", key, value)
+	}
+	return flag
+}
+
+func envInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		log.Fatalf("Invalid %s value: %q", key, value)
+	}
+	return number
+}
+
+// envInt64 accepts 0, for limits where 0 turns the limit off
+func envInt64(key string, fallback int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 0 {
+		log.Fatalf("Invalid %s value: %q", key, value)
+	}
+	return number
+}
@@ -0,0 +1,68 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetChannelIDsByUser returns the channels the user is subscribed to
+func (r *ChatRepository) GetChannelIDsByUser(user_id uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.ChatParticipant{}).
+		Joins("JOIN chats ON chats.id = chat_participants.chat_id").
+		Where("chat_participants.user_id = ? AND chats.type = ?", user_id, models.ChatTypeChannel).
+		Pluck("chat_participants.chat_id", &ids).Error
+	return ids, err
+}
+
+// AddSubscriber subscribes the user to the channel with its existing posts marked as read.
+// Subscriptions are not announced in the channel. Returns false if the user is already subscribed.
+func (r *ChatRepository) AddSubscriber(chat_id, user_id uint) (bool, error) {
+	added := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var chat models.Chat
+		if err := tx.Select("id", "last_message_id").First(&chat, chat_id).Error; err != nil {
+			return err
+		}
+
+		var err error
+		added, err = addSubscriber(tx, &chat, user_id)
+		return err
+	})
+
+	return added, err
+}
+
+// addSubscriber subscribes the user without a system message, invites use it to join channels
+func addSubscriber(tx *gorm.DB, chat *models.Chat, user_id uint) (bool, error) {
+	var watermark uint
+	if chat.LastMessageID != nil {
+		watermark = *chat.LastMessageID
+	}
+
+	subscriber := models.ChatParticipant{
+		ChatID:                 chat.ID,
+		UserID:                 user_id,
+		Role:                   models.ChatRoleMember,
+		JoinedAt:               time.Now().Truncate(time.Microsecond),
+		LastDeliveredMessageID: watermark,
+		LastReadMessageID:      watermark,
+	}
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&subscriber)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RemoveSubscriber unsubscribes the user from the channel, the owner can not unsubscribe.
+// Returns false if the user was not removed.
+func (r *ChatRepository) RemoveSubscriber(chat_id, user_id uint) (bool, error) {
+	result := r.db.Where("chat_id = ? AND user_id = ? AND role <> ?", chat_id, user_id, models.ChatRoleOwner).
+		Delete(&models.ChatParticipant{})
+	return result.RowsAffected > 0, result.Error
+}
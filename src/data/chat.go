@@ -1,9 +1,23 @@
 package data
 
 import (
+	"alexchatapp/src/models"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
 )
 
+const (
+	// DefaultMessagesPageSize is used when the caller does not specify a count
+	DefaultMessagesPageSize = 50
+	// MaxMessagesPageSize is the largest page of history returned at once
+	MaxMessagesPageSize = 100
+)
+
 // ChatRepository contains methods for database operations
 type ChatRepository struct {
 	db *gorm.DB
@@ -16,3 +30,287 @@ func NewChatRepository(db *gorm.DB) *ChatRepository {
 func (r *ChatRepository) GetDB() *gorm.DB {
 	return r.db
 }
+
+// CreateChat creates a chat together with its participants.
+// The creator is always added as a participant and owns the chat, direct chats have no owner.
+func (r *ChatRepository) CreateChat(chat *models.Chat, participant_ids []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Truncate(time.Microsecond)
+		if chat.LastActivityAt.IsZero() {
+			chat.LastActivityAt = now
+		}
+		if chat.Type == "" {
+			chat.Type = models.ChatTypeGroup
+		}
+		if err := tx.Create(chat).Error; err != nil {
+			return err
+		}
+
+		seen := map[uint]bool{}
+		var participants []models.ChatParticipant
+		for _, user_id := range append([]uint{chat.CreatorID}, participant_ids...) {
+			if seen[user_id] {
+				continue
+			}
+			seen[user_id] = true
+			role := models.ChatRoleMember
+			if user_id == chat.CreatorID && chat.Type != models.ChatTypeDirect {
+				role = models.ChatRoleOwner
+			}
+			participants = append(participants, models.ChatParticipant{
+				ChatID:   chat.ID,
+				UserID:   user_id,
+				Role:     role,
+				JoinedAt: now,
+			})
+		}
+
+		if err := tx.Create(&participants).Error; err != nil {
+			return err
+		}
+		chat.Participants = participants
+		return nil
+	})
+}
+
+// GetChatByID finds a chat by ID
+func (r *ChatRepository) GetChatByID(chat_id uint) (*models.Chat, error) {
+	var chat models.Chat
+	err := r.db.First(&chat, chat_id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+// GetChatsByUser returns all chats the user participates in
+func (r *ChatRepository) GetChatsByUser(user_id uint) ([]models.Chat, error) {
+	var chats []models.Chat
+	err := r.db.
+		Joins("JOIN chat_participants ON chat_participants.chat_id = chats.id").
+		Where("chat_participants.user_id = ?", user_id).
+		Order("chats.id").
+		Find(&chats).Error
+	return chats, err
+}
+
+// IsParticipant checks if the user is a member of the chat
+func (r *ChatRepository) IsParticipant(chat_id, user_id uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ChatParticipant{}).
+		Where("chat_id = ? AND user_id = ?", chat_id, user_id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetParticipantIDs returns IDs of all members of the chat
+func (r *ChatRepository) GetParticipantIDs(chat_id uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.ChatParticipant{}).
+		Where("chat_id = ?", chat_id).
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// CreateMessage stores a new message (with its mentions) in the chat and moves the chat's last activity
+func (r *ChatRepository) CreateMessage(message *models.Message) error {
+	if message.ChatID == 0 || message.SenderID == 0 {
+		return errors.New("message must have a chat and a sender")
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return createMessage(tx, message)
+	})
+}
+
+func createMessage(tx *gorm.DB, message *models.Message) error {
+	if message.CreatedAt.IsZero() {
+		// Postgres keeps microseconds, truncating keeps cursors built from this value exact
+		message.CreatedAt = time.Now().Truncate(time.Microsecond)
+	}
+
+	if err := tx.Create(message).Error; err != nil {
+		return err
+	}
+
+	if message.ThreadRootID != nil {
+		if err := addThreadReply(tx, message); err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(&models.Chat{}).
+		Where("id = ?", message.ChatID).
+		Updates(map[string]interface{}{
+			"last_message_id":  message.ID,
+			"last_activity_at": message.CreatedAt,
+		}).Error
+}
+
+// FindParticipantsByUsernames returns IDs of chat members with the given usernames
+func (r *ChatRepository) FindParticipantsByUsernames(chat_id uint, usernames []string) ([]uint, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	var ids []uint
+	err := r.db.Model(&models.ChatParticipant{}).
+		Joins("JOIN users ON users.id = chat_participants.user_id").
+		Where("chat_participants.chat_id = ? AND users.user_name IN ?", chat_id, usernames).
+		Pluck("chat_participants.user_id", &ids).Error
+	return ids, err
+}
+
+// PageDirection defines in which direction history is paged from a cursor
+type PageDirection int
+
+const (
+	PageBackward PageDirection = iota
+	PageForward
+)
+
+// MessageCursor points at a message in the (created_at, id) ordering of a chat
+type MessageCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+// CursorOf returns the cursor pointing at the message
+func CursorOf(message *models.Message) MessageCursor {
+	return MessageCursor{CreatedAt: message.CreatedAt, ID: message.ID}
+}
+
+// Encode returns the opaque form of the cursor passed to clients
+func (c MessageCursor) Encode() string {
+	return encodeCursor(c.CreatedAt, c.ID)
+}
+
+// DecodeMessageCursor parses a cursor produced by Encode
+func DecodeMessageCursor(token string) (MessageCursor, error) {
+	at, id, err := decodeCursor(token)
+	return MessageCursor{CreatedAt: at, ID: id}, err
+}
+
+// encodeCursor packs a (time, id) keyset position into an opaque token
+func encodeCursor(at time.Time, id uint) string {
+	raw := strconv.FormatInt(at.UnixMicro(), 10) + ":" + strconv.FormatUint(uint64(id), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(token string) (time.Time, uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, 0, errors.New("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return time.Time{}, 0, errors.New("invalid cursor")
+	}
+
+	micros, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errors.New("invalid cursor")
+	}
+	id, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, errors.New("invalid cursor")
+	}
+
+	return time.UnixMicro(micros), uint(id), nil
+}
+
+// ClampPageSize applies the default and maximum page size to a requested count
+func ClampPageSize(count int) int {
+	if count <= 0 {
+		return DefaultMessagesPageSize
+	}
+	if count > MaxMessagesPageSize {
+		return MaxMessagesPageSize
+	}
+	return count
+}
+
+// GetMessagesPage returns a page of messages visible to the viewer next to the cursor (the cursor message itself is excluded),
+// ordered from oldest to newest. A nil cursor starts from the latest messages when paging backward
+// and from the first message when paging forward. The second result reports if more messages
+// exist further in the same direction.
+func (r *ChatRepository) GetMessagesPage(chat_id, viewer_id uint, cursor *MessageCursor, direction PageDirection, count int) ([]models.Message, bool, error) {
+	return r.getMessagesPage(chat_id, viewer_id, cursor, direction, ClampPageSize(count))
+}
+
+// getMessagesPage does not clamp count, a zero count only checks if more messages exist
+func (r *ChatRepository) getMessagesPage(chat_id, viewer_id uint, cursor *MessageCursor, direction PageDirection, count int) ([]models.Message, bool, error) {
+	return pageMessages(r.db.Where("chat_id = ?", chat_id), viewer_id, cursor, direction, count)
+}
+
+// pageMessages pages through the messages selected by query, skipping the ones hidden by the viewer
+func pageMessages(query *gorm.DB, viewer_id uint, cursor *MessageCursor, direction PageDirection, count int) ([]models.Message, bool, error) {
+	query = query.Where("NOT EXISTS (SELECT 1 FROM hidden_messages WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = ?)", viewer_id)
+	if direction == PageForward {
+		if cursor != nil {
+			query = query.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+		}
+		query = query.Order("created_at ASC, id ASC")
+	} else {
+		if cursor != nil {
+			query = query.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+		}
+		query = query.Order("created_at DESC, id DESC")
+	}
+
+	// One extra row tells whether there is another page
+	var messages []models.Message
+	err := query.Limit(count + 1).Find(&messages).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(messages) > count
+	if hasMore {
+		messages = messages[:count]
+	}
+
+	if direction == PageBackward {
+		// Reverse to chronological order
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+	return messages, hasMore, nil
+}
+
+// GetMessagesAround returns a window of up to count messages centered on the given message,
+// together with flags telling if there are older and newer messages outside the window.
+// Near either end of the history the other side fills the rest of the window.
+// gorm.ErrRecordNotFound is returned if the message does not exist or the viewer hid it.
+func (r *ChatRepository) GetMessagesAround(chat_id, viewer_id, message_id uint, count int) ([]models.Message, bool, bool, error) {
+	count = ClampPageSize(count)
+
+	var target models.Message
+	err := r.db.Where("chat_id = ? AND id = ?", chat_id, message_id).
+		Where("NOT EXISTS (SELECT 1 FROM hidden_messages WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = ?)", viewer_id).
+		First(&target).Error
+	if err != nil {
+		return nil, false, false, err
+	}
+
+	cursor := CursorOf(&target)
+	after, hasAfter, err := r.getMessagesPage(chat_id, viewer_id, &cursor, PageForward, count-1-(count-1)/2)
+	if err != nil {
+		return nil, false, false, err
+	}
+	before, hasBefore, err := r.getMessagesPage(chat_id, viewer_id, &cursor, PageBackward, count-1-len(after))
+	if err != nil {
+		return nil, false, false, err
+	}
+	if hasAfter && len(before)+len(after) < count-1 {
+		after, hasAfter, err = r.getMessagesPage(chat_id, viewer_id, &cursor, PageForward, count-1-len(before))
+		if err != nil {
+			return nil, false, false, err
+		}
+	}
+
+	messages := append(before, target)
+	messages = append(messages, after...)
+	return messages, hasBefore, hasAfter, nil
+}
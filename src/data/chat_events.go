@@ -0,0 +1,50 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ChatEventsRepository contains database operations used by the Postgres pub/sub broker
+type ChatEventsRepository struct {
+	db *gorm.DB
+}
+
+// NewChatEventsRepository creates a new chat events repository instance
+func NewChatEventsRepository(db *gorm.DB) *ChatEventsRepository {
+	return &ChatEventsRepository{db: db}
+}
+
+// Notify sends a NOTIFY with the payload to the channel
+func (r *ChatEventsRepository) Notify(channel string, payload string) error {
+	return r.db.Exec("SELECT pg_notify(?, ?)", channel, payload).Error
+}
+
+// SavePayload stores a payload that does not fit into a NOTIFY and returns its ID
+func (r *ChatEventsRepository) SavePayload(data []byte) (uint, error) {
+	payload := models.ChatEventPayload{
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+	if err := r.db.Create(&payload).Error; err != nil {
+		return 0, err
+	}
+	return payload.ID, nil
+}
+
+// GetPayload loads a stored payload by ID
+func (r *ChatEventsRepository) GetPayload(id uint) ([]byte, error) {
+	var payload models.ChatEventPayload
+	err := r.db.First(&payload, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return payload.Data, nil
+}
+
+// DeletePayloadsBefore removes stored payloads created before the given time
+func (r *ChatEventsRepository) DeletePayloadsBefore(before time.Time) error {
+	return r.db.Where("created_at < ?", before).Delete(&models.ChatEventPayload{}).Error
+}
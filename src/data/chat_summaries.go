@@ -0,0 +1,151 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"time"
+)
+
+const (
+	// DefaultChatsPageSize is used when the caller does not specify a count
+	DefaultChatsPageSize = 30
+	// MaxChatsPageSize is the largest page of the chat list returned at once
+	MaxChatsPageSize = 100
+)
+
+// ChatCursor points at a chat in the (last_activity_at, id) ordering of the chat list
+type ChatCursor struct {
+	LastActivityAt time.Time
+	ID             uint
+}
+
+// Encode returns the opaque form of the cursor passed to clients
+func (c ChatCursor) Encode() string {
+	return encodeCursor(c.LastActivityAt, c.ID)
+}
+
+// DecodeChatCursor parses a cursor produced by Encode
+func DecodeChatCursor(token string) (ChatCursor, error) {
+	at, id, err := decodeCursor(token)
+	return ChatCursor{LastActivityAt: at, ID: id}, err
+}
+
+// ChatSummary is a chat list entry as seen by one participant
+type ChatSummary struct {
+	models.Chat
+	// LastMessage has no audio or image data loaded, nil for chats without messages the user can see
+	LastMessage  *models.Message `gorm:"-"`
+	UnreadCount  int64
+	MentionCount int64
+	// ThreadUnreadCount counts unread replies in the threads the user follows
+	ThreadUnreadCount int64
+	// DirectUserID is the other participant of a direct chat, whose name the chat takes
+	DirectUserID uint `gorm:"-"`
+}
+
+// Cursor returns the cursor pointing at the chat
+func (c *ChatSummary) Cursor() ChatCursor {
+	return ChatCursor{LastActivityAt: c.LastActivityAt, ID: c.ID}
+}
+
+// GetChatSummaries returns a page of the user's chats ordered by last activity, newest first.
+// Unread, mention and thread counters are calculated in the same query, last messages and
+// names of direct chats are loaded with one more query each for the whole page.
+func (r *ChatRepository) GetChatSummaries(user_id uint, cursor *ChatCursor, count int) ([]ChatSummary, bool, error) {
+	if count <= 0 {
+		count = DefaultChatsPageSize
+	}
+	if count > MaxChatsPageSize {
+		count = MaxChatsPageSize
+	}
+
+	// Counters skip what history does not show the user as new: system messages,
+	// deleted messages and messages the user hid
+	query := r.db.Table("chats").
+		Select(`chats.*,
+			(SELECT COUNT(*) FROM messages
+				WHERE messages.chat_id = chats.id
+				AND messages.id > chat_participants.last_read_message_id
+				AND messages.sender_id <> chat_participants.user_id
+				AND messages.kind <> ?
+				AND messages.deleted_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM hidden_messages
+					WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = chat_participants.user_id)) AS unread_count,
+			(SELECT COUNT(*) FROM message_mentions
+				JOIN messages ON messages.id = message_mentions.message_id
+				WHERE messages.chat_id = chats.id
+				AND messages.id > chat_participants.last_read_message_id
+				AND message_mentions.user_id = chat_participants.user_id
+				AND messages.deleted_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM hidden_messages
+					WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = chat_participants.user_id)) AS mention_count,
+			(SELECT COUNT(*) FROM thread_participants
+				JOIN messages ON messages.thread_root_id = thread_participants.root_message_id
+				WHERE messages.chat_id = chats.id
+				AND thread_participants.user_id = chat_participants.user_id
+				AND messages.id > thread_participants.last_read_message_id
+				AND messages.sender_id <> thread_participants.user_id
+				AND messages.deleted_at IS NULL
+				AND NOT EXISTS (SELECT 1 FROM hidden_messages
+					WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = chat_participants.user_id)) AS thread_unread_count`,
+			models.MessageKindSystem).
+		Joins("JOIN chat_participants ON chat_participants.chat_id = chats.id AND chat_participants.user_id = ?", user_id)
+	if cursor != nil {
+		query = query.Where("(chats.last_activity_at, chats.id) < (?, ?)", cursor.LastActivityAt, cursor.ID)
+	}
+
+	var summaries []ChatSummary
+	err := query.Order("chats.last_activity_at DESC, chats.id DESC").Limit(count + 1).Scan(&summaries).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(summaries) > count
+	if hasMore {
+		summaries = summaries[:count]
+	}
+
+	if err := r.loadLastMessages(summaries, user_id); err != nil {
+		return nil, false, err
+	}
+	if err := r.loadDirectPeers(summaries, user_id); err != nil {
+		return nil, false, err
+	}
+	return summaries, hasMore, nil
+}
+
+// loadLastMessages fills LastMessage of every summary with a single query.
+// It is the newest message the viewer did not hide, deleted messages stay as placeholders.
+func (r *ChatRepository) loadLastMessages(summaries []ChatSummary, viewer_id uint) error {
+	var chatIDs []uint
+	for _, summary := range summaries {
+		if summary.LastMessageID != nil {
+			chatIDs = append(chatIDs, summary.ID)
+		}
+	}
+	if len(chatIDs) == 0 {
+		return nil
+	}
+
+	latest := r.db.Model(&models.Message{}).
+		Select("MAX(messages.id)").
+		Where("messages.chat_id IN ?", chatIDs).
+		Where("NOT EXISTS (SELECT 1 FROM hidden_messages WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = ?)", viewer_id).
+		Group("messages.chat_id")
+
+	var messages []models.Message
+	err := r.db.Select("id", "chat_id", "sender_id", "kind", "text", "filename", "system", "status", "created_at", "deleted_at").
+		Where("id IN (?)", latest).
+		Find(&messages).Error
+	if err != nil {
+		return err
+	}
+
+	byChat := make(map[uint]*models.Message, len(messages))
+	for i := range messages {
+		byChat[messages[i].ChatID] = &messages[i]
+	}
+	for i := range summaries {
+		summaries[i].LastMessage = byChat[summaries[i].ID]
+	}
+	return nil
+}
@@ -24,5 +24,78 @@ func Initialize(dsn string) (*gorm.DB, error) {
 		return nil, err
 	}
 
+	err = db.AutoMigrate(&models.Chat{}, &models.ChatParticipant{}, &models.Message{}, &models.MessageReceipt{}, &models.MessageMention{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Full-text index of message text, the generated column keeps it current on insert and edit
+	err = db.Exec(`ALTER TABLE messages ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (to_tsvector('` + SearchConfig + `', coalesce(text, ''))) STORED`).Error
+	if err != nil {
+		return nil, err
+	}
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_messages_search ON messages USING GIN (search_vector)").Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Chats created before roles existed are owned by their creator
+	err = db.Exec(`UPDATE chat_participants SET role = ?
+		FROM chats
+		WHERE chats.id = chat_participants.chat_id AND chats.creator_id = chat_participants.user_id
+		AND NOT EXISTS (SELECT 1 FROM chat_participants owners WHERE owners.chat_id = chats.id AND owners.role = ?)`,
+		models.ChatRoleOwner, models.ChatRoleOwner).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.AutoMigrate(&models.MessageEdit{}, &models.HiddenMessage{}, &models.MessageReaction{}, &models.ThreadParticipant{}, &models.PinnedMessage{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.AutoMigrate(&models.ChatInvite{}, &models.ChatJoinRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.AutoMigrate(&models.Media{}, &models.Blob{}, &models.StorageUsage{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Media uploaded before stored content could differ from the upload
+	err = db.Exec(`UPDATE media SET upload_hash = hash, upload_size = size WHERE upload_hash IS NULL`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Content uploaded before blobs were tracked
+	err = db.Exec(`INSERT INTO blobs (hash, size, created_at)
+		SELECT hash, MIN(size), MIN(completed_at) FROM media WHERE completed_at IS NOT NULL GROUP BY hash
+		ON CONFLICT DO NOTHING`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Usage of media uploaded before quotas existed
+	err = db.Exec(`INSERT INTO storage_usages (user_id, bytes, updated_at)
+		SELECT owner_id, SUM(size), NOW() FROM media WHERE completed_at IS NOT NULL GROUP BY owner_id
+		ON CONFLICT DO NOTHING`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.AutoMigrate(&models.ChatEventPayload{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.AutoMigrate(&models.LinkPreview{})
+	if err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"errors"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// DirectPeer is the other participant of a direct chat as seen by one of its users
+type DirectPeer struct {
+	ChatID uint
+	UserID uint
+	// Name is the profile name of the user, or the username if the profile has no name
+	Name string
+}
+
+// directKey is the same for both users of the pair
+func directKey(user_id, other_id uint) string {
+	if other_id < user_id {
+		user_id, other_id = other_id, user_id
+	}
+	return strconv.FormatUint(uint64(user_id), 10) + ":" + strconv.FormatUint(uint64(other_id), 10)
+}
+
+// GetOrCreateDirectChat returns the direct chat of the two users, creating it if it does not exist yet.
+// The second result reports if the chat was created.
+func (r *ChatRepository) GetOrCreateDirectChat(user_id, target_id uint) (*models.Chat, bool, error) {
+	key := directKey(user_id, target_id)
+
+	chat, err := r.getDirectChat(key)
+	if err == nil {
+		return chat, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	chat = &models.Chat{
+		Type:      models.ChatTypeDirect,
+		CreatorID: user_id,
+		DirectKey: &key,
+	}
+	err = r.CreateChat(chat, []uint{target_id})
+	if err == nil {
+		return chat, true, nil
+	}
+
+	// A concurrent request may have created the chat first, the unique key rejected this one
+	existing, findErr := r.getDirectChat(key)
+	if findErr != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+func (r *ChatRepository) getDirectChat(key string) (*models.Chat, error) {
+	var chat models.Chat
+	err := r.db.Where("direct_key = ?", key).First(&chat).Error
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+// GetDirectPeers returns the other participant of every given direct chat of the user
+func (r *ChatRepository) GetDirectPeers(chat_ids []uint, user_id uint) (map[uint]DirectPeer, error) {
+	peers := make(map[uint]DirectPeer)
+	if len(chat_ids) == 0 {
+		return peers, nil
+	}
+
+	var rows []DirectPeer
+	err := r.db.Table("chat_participants").
+		Select("chat_participants.chat_id, chat_participants.user_id, COALESCE(NULLIF(profiles.profile_name, ''), users.user_name) AS name").
+		Joins("JOIN users ON users.id = chat_participants.user_id").
+		Joins("LEFT JOIN profiles ON profiles.user_id = chat_participants.user_id").
+		Where("chat_participants.chat_id IN ? AND chat_participants.user_id <> ?", chat_ids, user_id).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		peers[row.ChatID] = row
+	}
+	return peers, nil
+}
+
+// loadDirectPeers names direct chats of the summaries after the other participant
+func (r *ChatRepository) loadDirectPeers(summaries []ChatSummary, user_id uint) error {
+	var ids []uint
+	for _, summary := range summaries {
+		if summary.Type == models.ChatTypeDirect {
+			ids = append(ids, summary.ID)
+		}
+	}
+
+	peers, err := r.GetDirectPeers(ids, user_id)
+	if err != nil {
+		return err
+	}
+	for i := range summaries {
+		if peer, ok := peers[summaries[i].ID]; ok {
+			summaries[i].Name = peer.Name
+			summaries[i].DirectUserID = peer.UserID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInviteRevoked   = errors.New("invite was revoked")
+	ErrInviteExpired   = errors.New("invite has expired")
+	ErrInviteExhausted = errors.New("invite has reached its usage limit")
+	// ErrJoinRequestDecided is returned when a join request was already approved or rejected
+	ErrJoinRequestDecided = errors.New("join request is already decided")
+)
+
+// JoinResult tells what happened to a user who used an invite
+type JoinResult struct {
+	ChatID uint
+	UserID uint
+	// Joined is set when the user became a member
+	Joined bool
+	// Message announces the new member. Channels do not announce subscribers, whichever way they joined.
+	Message *models.Message
+	// Pending is set when the user was put into the join request queue
+	Pending bool
+}
+
+// generateInviteCode returns a random URL-safe code
+func generateInviteCode() (string, error) {
+	raw := make([]byte, 12)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// CreateInvite stores a new invite with a random code
+func (r *ChatRepository) CreateInvite(invite *models.ChatInvite) error {
+	code, err := generateInviteCode()
+	if err != nil {
+		return err
+	}
+	invite.Code = code
+	return r.db.Create(invite).Error
+}
+
+// RevokeInvite disables the invite of the chat, revoking twice keeps the first time
+func (r *ChatRepository) RevokeInvite(chat_id, invite_id uint) error {
+	result := r.db.Model(&models.ChatInvite{}).
+		Where("id = ? AND chat_id = ?", invite_id, chat_id).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetInvites returns invites of the chat that are not revoked, newest first
+func (r *ChatRepository) GetInvites(chat_id uint) ([]models.ChatInvite, error) {
+	var invites []models.ChatInvite
+	err := r.db.Where("chat_id = ? AND revoked_at IS NULL", chat_id).
+		Order("created_at DESC, id DESC").
+		Find(&invites).Error
+	return invites, err
+}
+
+// JoinByInvite adds the user to the chat of the invite, or queues a join request when the invite
+// requires approval. Returns gorm.ErrRecordNotFound for unknown codes.
+func (r *ChatRepository) JoinByInvite(code string, user_id uint) (*JoinResult, error) {
+	var result *JoinResult
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the invite so concurrent joins cannot exceed the usage limit
+		var invite models.ChatInvite
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code).First(&invite).Error
+		if err != nil {
+			return err
+		}
+		result = &JoinResult{ChatID: invite.ChatID, UserID: user_id}
+
+		var members int64
+		err = tx.Model(&models.ChatParticipant{}).
+			Where("chat_id = ? AND user_id = ?", invite.ChatID, user_id).
+			Count(&members).Error
+		if err != nil {
+			return err
+		}
+		if members > 0 {
+			return nil
+		}
+
+		if err := checkInviteUsable(&invite); err != nil {
+			return err
+		}
+
+		if invite.RequiresApproval {
+			result.Pending = true
+			request := models.ChatJoinRequest{
+				ChatID:    invite.ChatID,
+				UserID:    user_id,
+				InviteID:  invite.ID,
+				Status:    models.JoinRequestPending,
+				CreatedAt: time.Now(),
+			}
+			return tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "chat_id"}, {Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"invite_id":  request.InviteID,
+					"status":     request.Status,
+					"created_at": request.CreatedAt,
+					"decided_by": nil,
+					"decided_at": nil,
+				}),
+			}).Create(&request).Error
+		}
+
+		if err := useInvite(tx, invite.ID); err != nil {
+			return err
+		}
+		result.Joined, result.Message, err = joinChat(tx, invite.ChatID, user_id, user_id, models.SystemActionMemberJoined)
+		return err
+	})
+
+	return result, err
+}
+
+// GetPendingJoinRequests returns the join request queue of the chat, oldest first
+func (r *ChatRepository) GetPendingJoinRequests(chat_id uint) ([]models.ChatJoinRequest, error) {
+	var requests []models.ChatJoinRequest
+	err := r.db.Where("chat_id = ? AND status = ?", chat_id, models.JoinRequestPending).
+		Order("created_at, id").
+		Find(&requests).Error
+	return requests, err
+}
+
+// DecideJoinRequest approves or rejects a pending join request of the chat. Approving adds the user,
+// the invite must still be usable and its usage is counted on approval. The result is nil on rejection.
+// Returns ErrNotPermitted if the admin is no longer an admin.
+func (r *ChatRepository) DecideJoinRequest(chat_id, request_id, admin_id uint, approve bool) (*JoinResult, error) {
+	var result *JoinResult
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var request models.ChatJoinRequest
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND chat_id = ?", request_id, chat_id).
+			First(&request).Error
+		if err != nil {
+			return err
+		}
+		if request.Status != models.JoinRequestPending {
+			return ErrJoinRequestDecided
+		}
+
+		if approve {
+			// The invite may have been revoked, expired or used up while the request waited
+			var invite models.ChatInvite
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&invite, request.InviteID).Error
+			if err != nil {
+				return err
+			}
+			if err := checkInviteUsable(&invite); err != nil {
+				return err
+			}
+		}
+
+		// The admin may have been demoted while deciding
+		if _, err := lockChat(tx, chat_id); err != nil {
+			return err
+		}
+		if err := checkAdmin(tx, chat_id, admin_id); err != nil {
+			return err
+		}
+
+		decision := models.JoinRequestRejected
+		if approve {
+			decision = models.JoinRequestApproved
+		}
+		err = tx.Model(&request).Updates(map[string]interface{}{
+			"status":     decision,
+			"decided_by": admin_id,
+			"decided_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if !approve {
+			return nil
+		}
+		result = &JoinResult{ChatID: chat_id, UserID: request.UserID}
+		result.Joined, result.Message, err = joinChat(tx, chat_id, admin_id, request.UserID, models.SystemActionMembersAdded)
+		if err != nil || !result.Joined {
+			return err
+		}
+		return useInvite(tx, request.InviteID)
+	})
+
+	return result, err
+}
+
+// joinChat adds the user who came by an invite. Group members are announced with a system message,
+// channel subscribers are added silently the same way Subscribe adds them.
+func joinChat(tx *gorm.DB, chat_id, actor_id, user_id uint, action string) (bool, *models.Message, error) {
+	chat, err := lockChat(tx, chat_id)
+	if err != nil {
+		return false, nil, err
+	}
+	if chat.Type == models.ChatTypeChannel {
+		added, err := addSubscriber(tx, chat, user_id)
+		return added, nil, err
+	}
+
+	message, err := addParticipants(tx, chat_id, actor_id, []uint{user_id}, action)
+	return message != nil, message, err
+}
+
+func checkInviteUsable(invite *models.ChatInvite) error {
+	if invite.RevokedAt != nil {
+		return ErrInviteRevoked
+	}
+	if invite.ExpiresAt != nil && time.Now().After(*invite.ExpiresAt) {
+		return ErrInviteExpired
+	}
+	if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+		return ErrInviteExhausted
+	}
+	return nil
+}
+
+func useInvite(tx *gorm.DB, invite_id uint) error {
+	return tx.Model(&models.ChatInvite{}).
+		Where("id = ?", invite_id).
+		Update("uses", gorm.Expr("uses + 1")).Error
+}
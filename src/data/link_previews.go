@@ -0,0 +1,22 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// GetLinkPreviews returns the cached previews of the links, links without an entry are left out
+func (r *ChatRepository) GetLinkPreviews(urls []string) ([]models.LinkPreview, error) {
+	var previews []models.LinkPreview
+	if len(urls) == 0 {
+		return previews, nil
+	}
+	err := r.db.Where("url IN ?", urls).Find(&previews).Error
+	return previews, err
+}
+
+// SaveLinkPreview stores the preview of a link, replacing an expired one
+func (r *ChatRepository) SaveLinkPreview(preview *models.LinkPreview) error {
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(preview).Error
+}
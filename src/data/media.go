@@ -0,0 +1,365 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"errors"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrQuotaExceeded is returned when completed media would not fit into the owner's storage quota
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+type MediaRepository struct {
+	db *gorm.DB
+}
+
+func NewMediaRepository(db *gorm.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+// CreateMedia stores a new media record
+func (r *MediaRepository) CreateMedia(media *models.Media) error {
+	return r.db.Create(media).Error
+}
+
+// GetMedia returns the media record by ID
+func (r *MediaRepository) GetMedia(media_id uint) (*models.Media, error) {
+	var media models.Media
+	if err := r.db.First(&media, media_id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// FindOwnMedia returns the user's media uploaded with the given content, completed or not.
+// Completed media is preferred, gorm.ErrRecordNotFound is returned if there is none.
+func (r *MediaRepository) FindOwnMedia(owner_id uint, upload_hash string, upload_size int64) (*models.Media, error) {
+	var media models.Media
+	err := r.db.Where("owner_id = ? AND upload_hash = ? AND upload_size = ?", owner_id, upload_hash, upload_size).
+		Order("completed_at IS NULL, id").
+		First(&media).Error
+	if err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// ReuseBlob creates the media as complete if the same upload of the same type was already stored,
+// returning false otherwise. The stored content and its details are taken from the earlier media.
+// The blob stays locked until the media exists, so garbage collection cannot remove the content in between.
+// ErrQuotaExceeded is returned if the media does not fit into the quota, 0 means no quota.
+func (r *MediaRepository) ReuseBlob(media *models.Media, quota int64) (bool, error) {
+	reused := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var source models.Media
+		err := tx.Where("upload_hash = ? AND upload_size = ? AND mime_type = ? AND completed_at IS NOT NULL",
+			media.UploadHash, media.UploadSize, media.MimeType).
+			First(&source).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var blob models.Blob
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("hash = ?", source.Hash).First(&blob).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		reusing := *media
+		reusing.Hash = source.Hash
+		reusing.Size = source.Size
+		reusing.Width = source.Width
+		reusing.Height = source.Height
+		reusing.Thumbnails = source.Thumbnails
+		reusing.Duration = source.Duration
+		reusing.Waveform = source.Waveform
+		reusing.ScanStatus = source.ScanStatus
+		reusing.ScanSignature = source.ScanSignature
+		now := time.Now().Truncate(time.Microsecond)
+		reusing.CompletedAt = &now
+		if err := tx.Create(&reusing).Error; err != nil {
+			return err
+		}
+		if err := chargeStorage(tx, reusing.OwnerID, reusing.Size, quota); err != nil {
+			return err
+		}
+		*media = reusing
+		reused = true
+		return nil
+	})
+
+	return reused, err
+}
+
+// CompleteMedia registers the stored content of the media and marks the upload as complete,
+// saving the content details set by processing and charging its size to the owner's quota.
+// store is called with the blob locked and must make sure the content is stored.
+// gorm.ErrRecordNotFound is returned if the media was deleted in the meantime,
+// ErrQuotaExceeded if the media does not fit into the quota, 0 means no quota.
+// Media completed by another node in the meantime is not charged again, media gets its stored details.
+func (r *MediaRepository) CompleteMedia(media *models.Media, quota int64, store func() error) error {
+	now := time.Now().Truncate(time.Microsecond)
+	var stored *models.Media
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		blob := models.Blob{Hash: media.Hash, Size: media.Size, CreatedAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&blob).Error; err != nil {
+			return err
+		}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("hash = ?", media.Hash).First(&blob).Error
+		if err != nil {
+			return err
+		}
+
+		// The media row is locked before the usage row, in the same order as garbage collection
+		var current models.Media
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, media.ID).Error
+		if err != nil {
+			return err
+		}
+		if current.CompletedAt != nil {
+			stored = &current
+			return nil
+		}
+
+		completed := *media
+		completed.CompletedAt = &now
+		err = tx.Model(&completed).
+			Select("hash", "size", "mime_type", "width", "height", "thumbnails", "duration", "waveform", "completed_at").
+			Updates(&completed).Error
+		if err != nil {
+			return err
+		}
+		if err := chargeStorage(tx, media.OwnerID, media.Size, quota); err != nil {
+			return err
+		}
+
+		return store()
+	})
+	if err != nil {
+		return err
+	}
+
+	if stored != nil {
+		*media = *stored
+		return nil
+	}
+	media.CompletedAt = &now
+	return nil
+}
+
+// chargeStorage adds the bytes to the user's storage usage, failing with ErrQuotaExceeded if they do not fit
+func chargeStorage(tx *gorm.DB, user_id uint, bytes int64, quota int64) error {
+	usage := models.StorageUsage{UserID: user_id}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&usage).Error; err != nil {
+		return err
+	}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", user_id).First(&usage).Error
+	if err != nil {
+		return err
+	}
+
+	if quota > 0 && usage.Bytes+bytes > quota {
+		return ErrQuotaExceeded
+	}
+	return tx.Model(&usage).Update("bytes", gorm.Expr("bytes + ?", bytes)).Error
+}
+
+// GetStorageUsage returns the total size of the user's completed media
+func (r *MediaRepository) GetStorageUsage(user_id uint) (int64, error) {
+	var usage models.StorageUsage
+	err := r.db.Where("user_id = ?", user_id).First(&usage).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return usage.Bytes, err
+}
+
+// GetPendingScans returns up to limit hashes of completed content that was not scanned yet
+func (r *MediaRepository) GetPendingScans(limit int) ([]string, error) {
+	var hashes []string
+	err := r.db.Model(&models.Media{}).
+		Where("scan_status = ? AND completed_at IS NOT NULL", models.ScanPending).
+		Distinct().
+		Order("hash").
+		Limit(limit).
+		Pluck("hash", &hashes).Error
+	return hashes, err
+}
+
+// SetScanResult stores the scan result of the content for the completed media waiting for it.
+// It returns the messages attaching that media, so their chats can be told.
+func (r *MediaRepository) SetScanResult(hash string, scan_status string, signature string) ([]models.Message, error) {
+	var ids []uint
+	err := r.db.Model(&models.Media{}).
+		Where("hash = ? AND scan_status = ? AND completed_at IS NOT NULL", hash, models.ScanPending).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	err = r.db.Model(&models.Media{}).
+		Where("id IN ? AND scan_status = ?", ids, models.ScanPending).
+		Updates(map[string]any{"scan_status": scan_status, "scan_signature": signature}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []models.Message
+	err = r.db.Select("id", "chat_id", "media_id").
+		Where("media_id IN ? AND deleted_at IS NULL", ids).
+		Find(&messages).Error
+	return messages, err
+}
+
+// CanAccessMedia checks if the user uploaded the media or is a member of a chat where it was sent.
+// Avatars are visible to everyone.
+func (r *MediaRepository) CanAccessMedia(media *models.Media, user_id uint) (bool, error) {
+	if media.OwnerID == user_id {
+		return true, nil
+	}
+
+	var count int64
+	err := r.db.Model(&models.Profile{}).Where("avatar_media_id = ?", media.ID).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+
+	err = r.db.Model(&models.Message{}).
+		Joins("JOIN chat_participants ON chat_participants.chat_id = messages.chat_id AND chat_participants.user_id = ?", user_id).
+		Where("messages.media_id = ? AND messages.deleted_at IS NULL", media.ID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// LoadMessageMedia sets Media of the messages that reference uploaded content
+func (r *MediaRepository) LoadMessageMedia(messages []models.Message) error {
+	var ids []uint
+	for _, message := range messages {
+		if message.MediaID != nil && message.Media == nil {
+			ids = append(ids, *message.MediaID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var media []models.Media
+	if err := r.db.Where("id IN ?", ids).Find(&media).Error; err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*models.Media, len(media))
+	for i := range media {
+		byID[media[i].ID] = &media[i]
+	}
+	for i := range messages {
+		if messages[i].MediaID != nil && messages[i].Media == nil {
+			messages[i].Media = byID[*messages[i].MediaID]
+		}
+	}
+	return nil
+}
+
+// DeleteUnusedMedia deletes media created before the time that no message or profile references,
+// together with uploads that did not complete by then. It returns the deleted media
+// and gives the storage used by completed media back to the owners.
+func (r *MediaRepository) DeleteUnusedMedia(before time.Time) ([]models.Media, error) {
+	var deleted []models.Media
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Returning{}).
+			Where("created_at < ?", before).
+			Where(`completed_at IS NULL OR (
+				NOT EXISTS (SELECT 1 FROM messages WHERE messages.media_id = media.id) AND
+				NOT EXISTS (SELECT 1 FROM profiles WHERE profiles.avatar_media_id = media.id))`).
+			Delete(&deleted).Error
+		if err != nil {
+			return err
+		}
+
+		freed := make(map[uint]int64)
+		for _, media := range deleted {
+			if media.CompletedAt != nil {
+				freed[media.OwnerID] += media.Size
+			}
+		}
+		// Sorted, so concurrent collections lock usage rows in the same order
+		owners := make([]uint, 0, len(freed))
+		for owner := range freed {
+			owners = append(owners, owner)
+		}
+		slices.Sort(owners)
+
+		for _, owner := range owners {
+			err := tx.Model(&models.StorageUsage{}).
+				Where("user_id = ?", owner).
+				Update("bytes", gorm.Expr("bytes - ?", freed[owner])).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return deleted, err
+}
+
+// GetUnusedBlobs returns up to limit hashes of stored content no media uses
+func (r *MediaRepository) GetUnusedBlobs(limit int) ([]string, error) {
+	var hashes []string
+	err := r.db.Model(&models.Blob{}).
+		Where("NOT EXISTS (SELECT 1 FROM media WHERE media.hash = blobs.hash)").
+		Order("created_at").
+		Limit(limit).
+		Pluck("hash", &hashes).Error
+	return hashes, err
+}
+
+// DeleteBlob forgets the content if no media uses it, returning false if it is still in use.
+// remove is called with the blob locked and must delete the content from the store.
+func (r *MediaRepository) DeleteBlob(hash string, remove func() error) (bool, error) {
+	deleted := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var blob models.Blob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("hash = ?", hash).First(&blob).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Checked after locking: media reusing the content has committed by now
+		var count int64
+		if err := tx.Model(&models.Media{}).Where("hash = ?", hash).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		if err := remove(); err != nil {
+			return err
+		}
+		if err := tx.Delete(&blob).Error; err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+
+	return deleted, err
+}
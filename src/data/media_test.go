@@ -0,0 +1,155 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// testDB connects to the database in TEST_POSTGRES_CONNECTION. The tests collect unused media,
+// so point it at a scratch database.
+func testDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_POSTGRES_CONNECTION")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_CONNECTION is not set")
+	}
+	db, err := Initialize(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// testOwner creates a user owning the media of one test
+func testOwner(t *testing.T, db *gorm.DB) uint {
+	user := models.User{UserName: "media-" + randomHex(t, 6)}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatal(err)
+	}
+	return user.ID
+}
+
+func randomHex(t *testing.T, size int) string {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(raw)
+}
+
+func createUpload(t *testing.T, repo *MediaRepository, owner_id uint, hash string, size int64) *models.Media {
+	media := &models.Media{
+		OwnerID:    owner_id,
+		UploadHash: hash,
+		UploadSize: size,
+		Hash:       hash,
+		Size:       size,
+		MimeType:   "application/pdf",
+		CreatedAt:  time.Now(),
+	}
+	if err := repo.CreateMedia(media); err != nil {
+		t.Fatal(err)
+	}
+	return media
+}
+
+func expectUsage(t *testing.T, repo *MediaRepository, owner_id uint, want int64) {
+	t.Helper()
+	got, err := repo.GetStorageUsage(owner_id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("storage usage is %d, want %d", got, want)
+	}
+}
+
+func TestStorageAccounting(t *testing.T) {
+	db := testDB(t)
+	repo := NewMediaRepository(db)
+	owner := testOwner(t, db)
+	hash := randomHex(t, 32)
+	stored := func() error { return nil }
+
+	first := createUpload(t, repo, owner, hash, 100)
+	if err := repo.CompleteMedia(first, 150, stored); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, repo, owner, 100)
+
+	// Completing the same upload again, as another node would, does not charge it twice
+	again := *first
+	again.CompletedAt = nil
+	if err := repo.CompleteMedia(&again, 150, stored); err != nil || again.CompletedAt == nil {
+		t.Fatalf("CompleteMedia of completed media = %v, completed at %v", err, again.CompletedAt)
+	}
+	expectUsage(t, repo, owner, 100)
+
+	// The second copy does not fit into the quota, whether uploaded or reused
+	second := createUpload(t, repo, owner, randomHex(t, 32), 100)
+	if err := repo.CompleteMedia(second, 150, stored); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("CompleteMedia over the quota returned %v", err)
+	}
+	reused := &models.Media{OwnerID: owner, UploadHash: hash, UploadSize: 100, MimeType: "application/pdf", CreatedAt: time.Now()}
+	if _, err := repo.ReuseBlob(reused, 150); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("ReuseBlob over the quota returned %v", err)
+	}
+	expectUsage(t, repo, owner, 100)
+
+	// Reused content is charged again, every media counts on its own
+	ok, err := repo.ReuseBlob(reused, 0)
+	if err != nil || !ok {
+		t.Fatalf("ReuseBlob = %v, %v", ok, err)
+	}
+	if reused.Hash != hash || reused.CompletedAt == nil {
+		t.Fatalf("reused media %+v does not point at the stored content", reused)
+	}
+	expectUsage(t, repo, owner, 200)
+
+	// Media sent in a message is kept, the rest is collected and refunded,
+	// unfinished uploads were never charged
+	message := models.Message{SenderID: owner, Kind: models.MessageKindFile, MediaID: &reused.ID, CreatedAt: time.Now()}
+	if err := db.Create(&message).Error; err != nil {
+		t.Fatal(err)
+	}
+	deleted, err := repo.DeleteUnusedMedia(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	collected := map[uint]bool{}
+	for _, media := range deleted {
+		collected[media.ID] = true
+	}
+	if !collected[first.ID] || !collected[second.ID] || collected[reused.ID] {
+		t.Fatalf("collected %v, want media %d and %d but not %d", collected, first.ID, second.ID, reused.ID)
+	}
+	expectUsage(t, repo, owner, 100)
+
+	// The content stays while the kept media uses it
+	removed := 0
+	remove := func() error { removed++; return nil }
+	if ok, err := repo.DeleteBlob(hash, remove); err != nil || ok || removed != 0 {
+		t.Fatalf("DeleteBlob of used content = %v, %v, removed %d times", ok, err, removed)
+	}
+
+	if err := db.Delete(&message).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.DeleteUnusedMedia(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	expectUsage(t, repo, owner, 0)
+
+	if ok, err := repo.DeleteBlob(hash, remove); err != nil || !ok || removed != 1 {
+		t.Fatalf("DeleteBlob of unused content = %v, %v, removed %d times", ok, err, removed)
+	}
+	if ok, err := repo.DeleteBlob(hash, remove); err != nil || ok || removed != 1 {
+		t.Fatalf("DeleteBlob of deleted content = %v, %v, removed %d times", ok, err, removed)
+	}
+}
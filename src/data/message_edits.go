@@ -0,0 +1,114 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EditMessage replaces the text of the message, keeping the previous version in the edit history.
+// Mentions are recalculated from the new text.
+func (r *ChatRepository) EditMessage(message *models.Message, text string, editor_id uint, mentioned_ids []uint) error {
+	now := time.Now().Truncate(time.Microsecond)
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		edit := models.MessageEdit{
+			MessageID: message.ID,
+			EditorID:  editor_id,
+			Text:      message.Text,
+			EditedAt:  now,
+		}
+		if err := tx.Create(&edit).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&models.Message{}).
+			Where("id = ?", message.ID).
+			Updates(map[string]interface{}{"text": text, "edited_at": now}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("message_id = ?", message.ID).Delete(&models.MessageMention{}).Error; err != nil {
+			return err
+		}
+		var mentions []models.MessageMention
+		for _, user_id := range mentioned_ids {
+			mentions = append(mentions, models.MessageMention{MessageID: message.ID, UserID: user_id})
+		}
+		if len(mentions) > 0 {
+			if err := tx.Create(&mentions).Error; err != nil {
+				return err
+			}
+		}
+
+		message.Text = text
+		message.EditedAt = &now
+		return nil
+	})
+}
+
+// DeleteMessageForEveryone clears the content of the message and keeps it as a tombstone.
+// It reports whether the message was pinned, deleted messages are unpinned.
+func (r *ChatRepository) DeleteMessageForEveryone(message *models.Message) (bool, error) {
+	now := time.Now().Truncate(time.Microsecond)
+	unpinned := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Message{}).
+			Where("id = ?", message.ID).
+			Updates(map[string]interface{}{
+				"text":       "",
+				"audio_data": nil,
+				"image_data": nil,
+				"media_id":   nil,
+				"filename":   "",
+				"deleted_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		// Previous versions must not outlive the deleted message
+		if err := tx.Where("message_id = ?", message.ID).Delete(&models.MessageEdit{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("message_id = ?", message.ID).Delete(&models.MessageMention{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("message_id = ?", message.ID).Delete(&models.PinnedMessage{})
+		if result.Error != nil {
+			return result.Error
+		}
+		unpinned = result.RowsAffected > 0
+
+		message.Text = ""
+		message.AudioData = nil
+		message.ImageData = nil
+		message.MediaID = nil
+		message.Media = nil
+		message.DeletedAt = &now
+		return nil
+	})
+
+	return unpinned, err
+}
+
+// HideMessage deletes the message only for the user
+func (r *ChatRepository) HideMessage(message_id, user_id uint) error {
+	hidden := models.HiddenMessage{
+		MessageID: message_id,
+		UserID:    user_id,
+		HiddenAt:  time.Now(),
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&hidden).Error
+}
+
+// GetMessageEdits returns previous versions of the message, oldest first
+func (r *ChatRepository) GetMessageEdits(message_id uint) ([]models.MessageEdit, error) {
+	var edits []models.MessageEdit
+	err := r.db.Where("message_id = ?", message_id).Order("edited_at, id").Find(&edits).Error
+	return edits, err
+}
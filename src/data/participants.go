@@ -0,0 +1,358 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrNotPermitted is returned when the actor may not manage the member, roles are checked again
+// under the chat lock because they can change after the caller checked them
+var ErrNotPermitted = errors.New("actor is not allowed to manage the member")
+
+// GetParticipant returns the membership of the user in the chat
+func (r *ChatRepository) GetParticipant(chat_id, user_id uint) (*models.ChatParticipant, error) {
+	var participant models.ChatParticipant
+	err := r.db.Where("chat_id = ? AND user_id = ?", chat_id, user_id).First(&participant).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+// GetParticipants returns all members of the chat in the order they joined
+func (r *ChatRepository) GetParticipants(chat_id uint) ([]models.ChatParticipant, error) {
+	var participants []models.ChatParticipant
+	err := r.db.Where("chat_id = ?", chat_id).Order("joined_at, user_id").Find(&participants).Error
+	return participants, err
+}
+
+// AddParticipants adds the users who are not members yet and announces them with a system message.
+// New members start with the existing history marked as read. The message is nil if nobody was added.
+// Returns ErrNotPermitted if the actor is no longer an admin.
+func (r *ChatRepository) AddParticipants(chat_id, actor_id uint, user_ids []uint) (*models.Message, error) {
+	var message *models.Message
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockChat(tx, chat_id); err != nil {
+			return err
+		}
+		if err := checkAdmin(tx, chat_id, actor_id); err != nil {
+			return err
+		}
+
+		var err error
+		message, err = addParticipants(tx, chat_id, actor_id, user_ids, models.SystemActionMembersAdded)
+		return err
+	})
+
+	return message, err
+}
+
+func addParticipants(tx *gorm.DB, chat_id, actor_id uint, user_ids []uint, action string) (*models.Message, error) {
+	chat, err := lockChat(tx, chat_id)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []uint
+	err = tx.Model(&models.ChatParticipant{}).
+		Where("chat_id = ? AND user_id IN ?", chat_id, user_ids).
+		Pluck("user_id", &existing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[uint]bool{}
+	for _, user_id := range existing {
+		seen[user_id] = true
+	}
+
+	var watermark uint
+	if chat.LastMessageID != nil {
+		watermark = *chat.LastMessageID
+	}
+
+	now := time.Now().Truncate(time.Microsecond)
+	var participants []models.ChatParticipant
+	var added []uint
+	for _, user_id := range user_ids {
+		if seen[user_id] {
+			continue
+		}
+		seen[user_id] = true
+		added = append(added, user_id)
+		participants = append(participants, models.ChatParticipant{
+			ChatID:                 chat_id,
+			UserID:                 user_id,
+			Role:                   models.ChatRoleMember,
+			JoinedAt:               now,
+			LastDeliveredMessageID: watermark,
+			LastReadMessageID:      watermark,
+		})
+	}
+	if len(participants) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Create(&participants).Error; err != nil {
+		return nil, err
+	}
+
+	message := systemMessage(chat_id, actor_id, models.SystemPayload{
+		Action:  action,
+		UserIDs: added,
+	})
+	if err := createMessage(tx, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// RemoveParticipant removes the user from the chat on behalf of the actor and posts a system message.
+// Admins remove members, the owner removes anyone.
+// Returns gorm.ErrRecordNotFound if the user is not a member and ErrNotPermitted if the actor may not remove them.
+func (r *ChatRepository) RemoveParticipant(chat_id, actor_id, user_id uint) (*models.Message, error) {
+	var message *models.Message
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockChat(tx, chat_id); err != nil {
+			return err
+		}
+		if _, err := checkManage(tx, chat_id, actor_id, user_id, false); err != nil {
+			return err
+		}
+
+		if _, err := deleteParticipant(tx, chat_id, user_id); err != nil {
+			return err
+		}
+
+		message = systemMessage(chat_id, actor_id, models.SystemPayload{
+			Action:  models.SystemActionMemberRemoved,
+			UserIDs: []uint{user_id},
+		})
+		return createMessage(tx, message)
+	})
+
+	return message, err
+}
+
+// LeaveChat removes the user from the chat. When the owner leaves, ownership passes
+// to the longest-standing admin, or to the longest-standing member if there are no admins.
+// It returns the system messages posted into the chat.
+func (r *ChatRepository) LeaveChat(chat_id, user_id uint) ([]models.Message, error) {
+	var messages []models.Message
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockChat(tx, chat_id); err != nil {
+			return err
+		}
+
+		participant, err := deleteParticipant(tx, chat_id, user_id)
+		if err != nil {
+			return err
+		}
+
+		left := systemMessage(chat_id, user_id, models.SystemPayload{
+			Action:  models.SystemActionMemberLeft,
+			UserIDs: []uint{user_id},
+		})
+		if err := createMessage(tx, left); err != nil {
+			return err
+		}
+		messages = append(messages, *left)
+
+		if participant.Role != models.ChatRoleOwner {
+			return nil
+		}
+
+		var successor models.ChatParticipant
+		err = tx.Where("chat_id = ?", chat_id).
+			Order(clause.Expr{SQL: "CASE WHEN role = ? THEN 0 ELSE 1 END, joined_at, user_id", Vars: []interface{}{models.ChatRoleAdmin}}).
+			First(&successor).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The last member left, nobody to pass the chat to
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := setRole(tx, chat_id, successor.UserID, models.ChatRoleOwner); err != nil {
+			return err
+		}
+		transferred := systemMessage(chat_id, user_id, models.SystemPayload{
+			Action:  models.SystemActionOwnershipTransferred,
+			UserIDs: []uint{successor.UserID},
+			Role:    models.ChatRoleOwner,
+		})
+		if err := createMessage(tx, transferred); err != nil {
+			return err
+		}
+		messages = append(messages, *transferred)
+		return nil
+	})
+
+	return messages, err
+}
+
+// TransferOwnership makes the member the owner of the chat, the previous owner becomes an admin.
+// Returns gorm.ErrRecordNotFound if the new owner is not a member and ErrNotPermitted if owner_id is not the owner.
+func (r *ChatRepository) TransferOwnership(chat_id, owner_id, new_owner_id uint) (*models.Message, error) {
+	var message *models.Message
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockChat(tx, chat_id); err != nil {
+			return err
+		}
+		if _, err := checkManage(tx, chat_id, owner_id, new_owner_id, true); err != nil {
+			return err
+		}
+
+		if err := setRole(tx, chat_id, new_owner_id, models.ChatRoleOwner); err != nil {
+			return err
+		}
+		if err := setRole(tx, chat_id, owner_id, models.ChatRoleAdmin); err != nil {
+			return err
+		}
+
+		message = systemMessage(chat_id, owner_id, models.SystemPayload{
+			Action:  models.SystemActionOwnershipTransferred,
+			UserIDs: []uint{new_owner_id},
+			Role:    models.ChatRoleOwner,
+		})
+		return createMessage(tx, message)
+	})
+
+	return message, err
+}
+
+// SetParticipantRole changes the role of a member between admin and member, only the owner can do it.
+// The message is nil if the member already has the role.
+// Returns gorm.ErrRecordNotFound if the user is not a member and ErrNotPermitted if the actor is not the owner.
+func (r *ChatRepository) SetParticipantRole(chat_id, actor_id, user_id uint, role string) (*models.Message, error) {
+	var message *models.Message
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := lockChat(tx, chat_id); err != nil {
+			return err
+		}
+
+		participant, err := checkManage(tx, chat_id, actor_id, user_id, true)
+		if err != nil {
+			return err
+		}
+		if participant.Role == role {
+			return nil
+		}
+
+		if err := setRole(tx, chat_id, user_id, role); err != nil {
+			return err
+		}
+
+		message = systemMessage(chat_id, actor_id, models.SystemPayload{
+			Action:  models.SystemActionRoleChanged,
+			UserIDs: []uint{user_id},
+			Role:    role,
+		})
+		return createMessage(tx, message)
+	})
+
+	return message, err
+}
+
+// checkManage loads the memberships of the actor and of the member they manage inside the transaction
+// holding the chat lock. Nobody manages the owner, admins manage members unless owner_only is set,
+// the owner manages everyone else.
+func checkManage(tx *gorm.DB, chat_id, actor_id, user_id uint, owner_only bool) (*models.ChatParticipant, error) {
+	var actor models.ChatParticipant
+	err := tx.Where("chat_id = ? AND user_id = ?", chat_id, actor_id).First(&actor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotPermitted
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var target models.ChatParticipant
+	err = tx.Where("chat_id = ? AND user_id = ?", chat_id, user_id).First(&target).Error
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case target.Role == models.ChatRoleOwner:
+		return nil, ErrNotPermitted
+	case actor.Role == models.ChatRoleOwner:
+		return &target, nil
+	case owner_only || actor.Role != models.ChatRoleAdmin || target.Role != models.ChatRoleMember:
+		return nil, ErrNotPermitted
+	}
+	return &target, nil
+}
+
+// checkAdmin checks inside the transaction holding the chat lock that the actor is still an admin or the owner
+func checkAdmin(tx *gorm.DB, chat_id, actor_id uint) error {
+	var actor models.ChatParticipant
+	err := tx.Where("chat_id = ? AND user_id = ?", chat_id, actor_id).First(&actor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotPermitted
+	}
+	if err != nil {
+		return err
+	}
+	if actor.Role != models.ChatRoleOwner && actor.Role != models.ChatRoleAdmin {
+		return ErrNotPermitted
+	}
+	return nil
+}
+
+// lockChat serializes membership changes of one chat
+func lockChat(tx *gorm.DB, chat_id uint) (*models.Chat, error) {
+	var chat models.Chat
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&chat, chat_id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &chat, nil
+}
+
+func deleteParticipant(tx *gorm.DB, chat_id, user_id uint) (*models.ChatParticipant, error) {
+	var participant models.ChatParticipant
+	err := tx.Where("chat_id = ? AND user_id = ?", chat_id, user_id).First(&participant).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Where("chat_id = ? AND user_id = ?", chat_id, user_id).Delete(&models.ChatParticipant{}).Error
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func setRole(tx *gorm.DB, chat_id, user_id uint, role string) error {
+	result := tx.Model(&models.ChatParticipant{}).
+		Where("chat_id = ? AND user_id = ?", chat_id, user_id).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func systemMessage(chat_id, actor_id uint, payload models.SystemPayload) *models.Message {
+	return &models.Message{
+		ChatID:   chat_id,
+		SenderID: actor_id,
+		Kind:     models.MessageKindSystem,
+		System:   &payload,
+		Status:   models.MessageStatusSent,
+	}
+}
@@ -0,0 +1,94 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTooManyPins is returned when the chat already has the maximum number of pinned messages
+var ErrTooManyPins = errors.New("chat has too many pinned messages")
+
+// PinMessage pins the message in its chat, rejecting it with ErrTooManyPins once max_pins are pinned.
+// It returns false if the message was already pinned.
+func (r *ChatRepository) PinMessage(chat_id, message_id, user_id uint, max_pins int) (bool, error) {
+	pinned := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the chat so concurrent pins cannot exceed the limit
+		if _, err := lockChat(tx, chat_id); err != nil {
+			return err
+		}
+
+		var count int64
+		err := tx.Model(&models.PinnedMessage{}).Where("chat_id = ?", chat_id).Count(&count).Error
+		if err != nil {
+			return err
+		}
+
+		pin := models.PinnedMessage{
+			ChatID:    chat_id,
+			MessageID: message_id,
+			PinnedBy:  user_id,
+			PinnedAt:  time.Now().Truncate(time.Microsecond),
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&pin)
+		if result.Error != nil {
+			return result.Error
+		}
+		pinned = result.RowsAffected > 0
+
+		// Checked after the insert so pinning an already pinned message succeeds at the limit
+		if pinned && count >= int64(max_pins) {
+			return ErrTooManyPins
+		}
+		return nil
+	})
+
+	return pinned, err
+}
+
+// UnpinMessage unpins the message, returning false if it was not pinned
+func (r *ChatRepository) UnpinMessage(chat_id, message_id uint) (bool, error) {
+	result := r.db.Where("chat_id = ? AND message_id = ?", chat_id, message_id).Delete(&models.PinnedMessage{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetPinnedMessages returns the pins of the chat with their messages, most recently pinned first.
+// Messages hidden by the viewer are left out.
+func (r *ChatRepository) GetPinnedMessages(chat_id, viewer_id uint) ([]models.PinnedMessage, error) {
+	var pins []models.PinnedMessage
+	err := r.db.Where("chat_id = ?", chat_id).
+		Where("NOT EXISTS (SELECT 1 FROM hidden_messages WHERE hidden_messages.message_id = pinned_messages.message_id AND hidden_messages.user_id = ?)", viewer_id).
+		Order("pinned_at DESC, message_id DESC").
+		Find(&pins).Error
+	if err != nil || len(pins) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uint, len(pins))
+	for i, pin := range pins {
+		ids[i] = pin.MessageID
+	}
+
+	var messages []models.Message
+	if err := r.db.Where("id IN ?", ids).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.Message, len(messages))
+	for i := range messages {
+		byID[messages[i].ID] = &messages[i]
+	}
+
+	result := pins[:0]
+	for _, pin := range pins {
+		if message, ok := byID[pin.MessageID]; ok {
+			pin.Message = message
+			result = append(result, pin)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTooManyReactions is returned when a message already has the maximum number of distinct reactions
+var ErrTooManyReactions = errors.New("message has too many different reactions")
+
+// ReactionSummary is the aggregated count of one emoji on a message as seen by a viewer
+type ReactionSummary struct {
+	MessageID   uint
+	Emoji       string
+	Count       int64
+	ReactedByMe bool
+}
+
+// AddReaction adds the user's reaction to the message. A new emoji is rejected with
+// ErrTooManyReactions once the message has max_distinct different emoji.
+// It returns false if the user already reacted with this emoji.
+func (r *ChatRepository) AddReaction(message_id, user_id uint, emoji string, max_distinct int) (bool, error) {
+	added := false
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the message so concurrent reactions cannot exceed the limit
+		var message models.Message
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&message, message_id).Error
+		if err != nil {
+			return err
+		}
+
+		var exists int64
+		err = tx.Model(&models.MessageReaction{}).
+			Where("message_id = ? AND emoji = ?", message_id, emoji).
+			Count(&exists).Error
+		if err != nil {
+			return err
+		}
+
+		if exists == 0 {
+			var distinct int64
+			err = tx.Model(&models.MessageReaction{}).
+				Where("message_id = ?", message_id).
+				Distinct("emoji").
+				Count(&distinct).Error
+			if err != nil {
+				return err
+			}
+			if distinct >= int64(max_distinct) {
+				return ErrTooManyReactions
+			}
+		}
+
+		reaction := models.MessageReaction{
+			MessageID: message_id,
+			UserID:    user_id,
+			Emoji:     emoji,
+			CreatedAt: time.Now(),
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reaction)
+		if result.Error != nil {
+			return result.Error
+		}
+		added = result.RowsAffected > 0
+		return nil
+	})
+
+	return added, err
+}
+
+// RemoveReaction removes the user's reaction, returning false if there was none
+func (r *ChatRepository) RemoveReaction(message_id, user_id uint, emoji string) (bool, error) {
+	result := r.db.Where("message_id = ? AND user_id = ? AND emoji = ?", message_id, user_id, emoji).
+		Delete(&models.MessageReaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetReactionSummaries aggregates reactions of the messages for the viewer with a single query.
+// The result maps message IDs to their reactions ordered by first use.
+func (r *ChatRepository) GetReactionSummaries(message_ids []uint, viewer_id uint) (map[uint][]ReactionSummary, error) {
+	summaries := make(map[uint][]ReactionSummary)
+	if len(message_ids) == 0 {
+		return summaries, nil
+	}
+
+	var rows []struct {
+		MessageID   uint
+		Emoji       string
+		Count       int64
+		ReactedByMe int64
+	}
+	err := r.db.Model(&models.MessageReaction{}).
+		Select("message_id, emoji, COUNT(*) AS count, MAX(CASE WHEN user_id = ? THEN 1 ELSE 0 END) AS reacted_by_me", viewer_id).
+		Where("message_id IN ?", message_ids).
+		Group("message_id, emoji").
+		Order("message_id, MIN(created_at)").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		summaries[row.MessageID] = append(summaries[row.MessageID], ReactionSummary{
+			MessageID:   row.MessageID,
+			Emoji:       row.Emoji,
+			Count:       row.Count,
+			ReactedByMe: row.ReactedByMe > 0,
+		})
+	}
+	return summaries, nil
+}
@@ -0,0 +1,202 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MarkDelivered records that the user received every message of the chat up to up_to.
+// It returns the messages whose aggregated status changed, their senders should be notified.
+func (r *ChatRepository) MarkDelivered(chat_id, user_id, up_to uint) ([]models.Message, error) {
+	return r.markReceipts(chat_id, user_id, up_to, false)
+}
+
+// MarkRead records that the user read every message of the chat up to up_to.
+// Reading implies delivery. It returns the messages whose aggregated status changed.
+func (r *ChatRepository) MarkRead(chat_id, user_id, up_to uint) ([]models.Message, error) {
+	return r.markReceipts(chat_id, user_id, up_to, true)
+}
+
+func (r *ChatRepository) markReceipts(chat_id, user_id, up_to uint, read bool) ([]models.Message, error) {
+	var changed []models.Message
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the participant row so concurrent acks of one user do not interleave
+		var participant models.ChatParticipant
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("chat_id = ? AND user_id = ?", chat_id, user_id).
+			First(&participant).Error
+		if err != nil {
+			return err
+		}
+
+		// Message IDs are global, so the watermark must not move past the last message of this chat
+		var last uint
+		err = tx.Model(&models.Message{}).
+			Where("chat_id = ? AND id <= ?", chat_id, up_to).
+			Select("COALESCE(MAX(id), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		watermark := participant.LastDeliveredMessageID
+		if read {
+			watermark = participant.LastReadMessageID
+		}
+		if last <= watermark {
+			return nil
+		}
+
+		var chatType string
+		err = tx.Model(&models.Chat{}).Where("id = ?", chat_id).Select("type").Scan(&chatType).Error
+		if err != nil {
+			return err
+		}
+		if chatType == models.ChatTypeChannel {
+			return markChannelRead(tx, &participant, watermark, last, read)
+		}
+
+		now := time.Now()
+		var touched []uint
+		if read {
+			err = tx.Raw(`INSERT INTO message_receipts (message_id, user_id, delivered_at, read_at)
+				SELECT id, ?, ?, ? FROM messages
+				WHERE chat_id = ? AND id > ? AND id <= ? AND sender_id <> ?
+				ON CONFLICT (message_id, user_id) DO UPDATE
+				SET read_at = EXCLUDED.read_at,
+					delivered_at = COALESCE(message_receipts.delivered_at, EXCLUDED.delivered_at)
+				WHERE message_receipts.read_at IS NULL
+				RETURNING message_id`,
+				user_id, now, now, chat_id, watermark, last, user_id).Scan(&touched).Error
+		} else {
+			err = tx.Raw(`INSERT INTO message_receipts (message_id, user_id, delivered_at)
+				SELECT id, ?, ? FROM messages
+				WHERE chat_id = ? AND id > ? AND id <= ? AND sender_id <> ?
+				ON CONFLICT (message_id, user_id) DO NOTHING
+				RETURNING message_id`,
+				user_id, now, chat_id, watermark, last, user_id).Scan(&touched).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{}
+		if last > participant.LastDeliveredMessageID {
+			updates["last_delivered_message_id"] = last
+		}
+		if read {
+			updates["last_read_message_id"] = last
+		}
+		err = tx.Model(&models.ChatParticipant{}).
+			Where("chat_id = ? AND user_id = ?", chat_id, user_id).
+			Updates(updates).Error
+		if err != nil {
+			return err
+		}
+
+		changed, err = refreshMessageStatuses(tx, chat_id, touched)
+		return err
+	})
+
+	return changed, err
+}
+
+// markChannelRead moves the watermarks of a channel subscriber. Channels keep no per-user receipts,
+// reading counts a view of every post between the watermarks instead.
+func markChannelRead(tx *gorm.DB, participant *models.ChatParticipant, watermark, last uint, read bool) error {
+	updates := map[string]interface{}{}
+	if last > participant.LastDeliveredMessageID {
+		updates["last_delivered_message_id"] = last
+	}
+	if read {
+		updates["last_read_message_id"] = last
+	}
+	err := tx.Model(&models.ChatParticipant{}).
+		Where("chat_id = ? AND user_id = ?", participant.ChatID, participant.UserID).
+		Updates(updates).Error
+	if err != nil || !read {
+		return err
+	}
+
+	return tx.Model(&models.Message{}).
+		Where("chat_id = ? AND id > ? AND id <= ? AND sender_id <> ?", participant.ChatID, watermark, last, participant.UserID).
+		Update("view_count", gorm.Expr("view_count + 1")).Error
+}
+
+// refreshMessageStatuses recalculates the aggregated status of the messages:
+// RECEIVED once every recipient got the message, READ once every recipient read it.
+// Recipients are the current participants other than the sender who joined before the message,
+// receipts of users who left the chat are not counted.
+func refreshMessageStatuses(tx *gorm.DB, chat_id uint, message_ids []uint) ([]models.Message, error) {
+	if len(message_ids) == 0 {
+		return nil, nil
+	}
+
+	recipient := `chat_participants.chat_id = messages.chat_id
+		AND chat_participants.user_id <> messages.sender_id
+		AND chat_participants.joined_at <= messages.created_at`
+
+	var rows []struct {
+		models.Message
+		RecipientCount int64
+		DeliveredCount int64
+		ReadCount      int64
+	}
+	err := tx.Table("messages").
+		Select(`messages.*,
+			(SELECT COUNT(*) FROM chat_participants WHERE `+recipient+`) AS recipient_count,
+			COUNT(message_receipts.delivered_at) AS delivered_count,
+			COUNT(message_receipts.read_at) AS read_count`).
+		Joins(`LEFT JOIN message_receipts ON message_receipts.message_id = messages.id
+			AND EXISTS (SELECT 1 FROM chat_participants WHERE `+recipient+`
+				AND chat_participants.user_id = message_receipts.user_id)`).
+		Where("messages.chat_id = ? AND messages.id IN ?", chat_id, message_ids).
+		Group("messages.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []models.Message
+	for _, row := range rows {
+		recipients := max(row.RecipientCount, 1)
+		newStatus := row.Status
+		if row.ReadCount >= recipients {
+			newStatus = models.MessageStatusRead
+		} else if row.DeliveredCount >= recipients {
+			newStatus = models.MessageStatusReceived
+		}
+		if newStatus <= row.Status {
+			continue
+		}
+
+		err := tx.Model(&models.Message{}).Where("id = ?", row.ID).Update("status", newStatus).Error
+		if err != nil {
+			return nil, err
+		}
+		row.Message.Status = newStatus
+		changed = append(changed, row.Message)
+	}
+	return changed, nil
+}
+
+// GetMessageByID finds a message of the chat by ID
+func (r *ChatRepository) GetMessageByID(chat_id, message_id uint) (*models.Message, error) {
+	var message models.Message
+	err := r.db.Where("chat_id = ? AND id = ?", chat_id, message_id).First(&message).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// GetMessageReceipts returns receipts of every recipient who received or read the message
+func (r *ChatRepository) GetMessageReceipts(message_id uint) ([]models.MessageReceipt, error) {
+	var receipts []models.MessageReceipt
+	err := r.db.Where("message_id = ?", message_id).Order("user_id").Find(&receipts).Error
+	return receipts, err
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+	"time"
+)
+
+const (
+	// SearchConfig is the text search configuration of the message index.
+	// It does not stem or drop stop words, so chats in any language are matched word by word.
+	SearchConfig = "simple"
+
+	// Matched words in snippets are wrapped in these markers
+	SnippetStart = "<mark>"
+	SnippetStop  = "</mark>"
+
+	headlineOptions = "StartSel=" + SnippetStart + ", StopSel=" + SnippetStop + ", MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=\" … \""
+
+	// escapedText is the message text with HTML special characters escaped. ts_headline copies the text
+	// around the markers as it is, so tags written by the sender would reach clients rendering the snippet.
+	escapedText = `replace(replace(replace(replace(replace(messages.text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+)
+
+// SearchFilter narrows a message search, zero values are not applied
+type SearchFilter struct {
+	ChatID   uint
+	SenderID uint
+	After    time.Time
+	Before   time.Time
+}
+
+// SearchResult is a message matching a search with a highlighted snippet of its text.
+// The snippet is HTML escaped, the markers are the only tags in it.
+type SearchResult struct {
+	models.Message
+	Snippet string
+}
+
+// SearchMessages finds messages matching the query in the chats of the viewer, newest first.
+// Deleted messages and messages hidden by the viewer are not returned.
+// The second result reports if more results exist after the page.
+func (r *ChatRepository) SearchMessages(viewer_id uint, text string, filter SearchFilter, cursor *MessageCursor, count int) ([]SearchResult, bool, error) {
+	count = ClampPageSize(count)
+
+	tsQuery := "websearch_to_tsquery('" + SearchConfig + "', ?)"
+	query := r.db.Model(&models.Message{}).
+		Select("messages.*, ts_headline('"+SearchConfig+"', "+escapedText+", "+tsQuery+", ?) AS snippet", text, headlineOptions).
+		Joins("JOIN chat_participants ON chat_participants.chat_id = messages.chat_id AND chat_participants.user_id = ?", viewer_id).
+		Where("messages.search_vector @@ "+tsQuery, text).
+		Where("messages.deleted_at IS NULL").
+		Where("NOT EXISTS (SELECT 1 FROM hidden_messages WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = ?)", viewer_id)
+
+	if filter.ChatID != 0 {
+		query = query.Where("messages.chat_id = ?", filter.ChatID)
+	}
+	if filter.SenderID != 0 {
+		query = query.Where("messages.sender_id = ?", filter.SenderID)
+	}
+	if !filter.After.IsZero() {
+		query = query.Where("messages.created_at >= ?", filter.After)
+	}
+	if !filter.Before.IsZero() {
+		query = query.Where("messages.created_at < ?", filter.Before)
+	}
+	if cursor != nil {
+		query = query.Where("(messages.created_at, messages.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	// One extra row tells whether there is another page
+	var results []SearchResult
+	err := query.Order("messages.created_at DESC, messages.id DESC").Limit(count + 1).Scan(&results).Error
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(results) > count
+	if hasMore {
+		results = results[:count]
+	}
+	return results, hasMore, nil
+}
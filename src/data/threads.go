@@ -0,0 +1,144 @@
+package data
+
+import (
+	"alexchatapp/src/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// addThreadReply counts the reply on its thread root and makes the sender follow the thread.
+// The author of the root follows the thread from its first reply.
+func addThreadReply(tx *gorm.DB, reply *models.Message) error {
+	root_id := *reply.ThreadRootID
+
+	err := tx.Model(&models.Message{}).
+		Where("id = ?", root_id).
+		Update("reply_count", gorm.Expr("reply_count + 1")).Error
+	if err != nil {
+		return err
+	}
+
+	err = tx.Exec(`INSERT INTO thread_participants (root_message_id, user_id, last_read_message_id)
+		SELECT id, sender_id, id FROM messages WHERE id = ?
+		ON CONFLICT (root_message_id, user_id) DO NOTHING`, root_id).Error
+	if err != nil {
+		return err
+	}
+
+	// Replying means the sender has read the thread up to the reply
+	participant := models.ThreadParticipant{
+		RootMessageID:     root_id,
+		UserID:            reply.SenderID,
+		LastReadMessageID: reply.ID,
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "root_message_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_read_message_id"}),
+	}).Create(&participant).Error
+}
+
+// GetThreadPage returns a page of replies of the thread visible to the viewer, oldest first
+func (r *ChatRepository) GetThreadPage(root_id, viewer_id uint, cursor *MessageCursor, count int) ([]models.Message, bool, error) {
+	return pageMessages(r.db.Where("thread_root_id = ?", root_id), viewer_id, cursor, PageForward, ClampPageSize(count))
+}
+
+// MarkThreadRead moves the user's read watermark of the thread up to up_to and starts following the thread.
+// It returns the number of replies left unread.
+func (r *ChatRepository) MarkThreadRead(root_id, user_id, up_to uint) (int64, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Message IDs are global, so the watermark must not move past the last reply of this thread
+		var last uint
+		err := tx.Model(&models.Message{}).
+			Where("thread_root_id = ? AND id <= ?", root_id, up_to).
+			Select("COALESCE(MAX(id), ?)", root_id).
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		participant := models.ThreadParticipant{
+			RootMessageID:     root_id,
+			UserID:            user_id,
+			LastReadMessageID: last,
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "root_message_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_read_message_id"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "thread_participants.last_read_message_id < excluded.last_read_message_id"},
+			}},
+		}).Create(&participant).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	unread, err := r.GetThreadUnreadCounts([]uint{root_id}, user_id)
+	return unread[root_id], err
+}
+
+// GetThreadUnreadCounts returns the number of unread replies in the threads the user follows.
+// Threads without unread replies or not followed by the user are missing from the result.
+func (r *ChatRepository) GetThreadUnreadCounts(root_ids []uint, user_id uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+	if len(root_ids) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		RootMessageID uint
+		UnreadCount   int64
+	}
+	err := r.db.Table("thread_participants").
+		Select("thread_participants.root_message_id, COUNT(messages.id) AS unread_count").
+		Joins(`JOIN messages ON messages.thread_root_id = thread_participants.root_message_id
+			AND messages.id > thread_participants.last_read_message_id
+			AND messages.sender_id <> thread_participants.user_id
+			AND messages.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM hidden_messages
+				WHERE hidden_messages.message_id = messages.id AND hidden_messages.user_id = thread_participants.user_id)`).
+		Where("thread_participants.user_id = ? AND thread_participants.root_message_id IN ?", user_id, root_ids).
+		Group("thread_participants.root_message_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.RootMessageID] = row.UnreadCount
+	}
+	return counts, nil
+}
+
+// LoadReplyQuotes fills ReplyTo of every reply with a single query
+func (r *ChatRepository) LoadReplyQuotes(messages []models.Message) error {
+	var ids []uint
+	for _, message := range messages {
+		if message.ReplyToID != nil && message.ReplyTo == nil {
+			ids = append(ids, *message.ReplyToID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var quoted []models.Message
+	err := r.db.Select("id", "chat_id", "sender_id", "kind", "text", "filename", "system", "created_at", "deleted_at").
+		Where("id IN ?", ids).
+		Find(&quoted).Error
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*models.Message, len(quoted))
+	for i := range quoted {
+		byID[quoted[i].ID] = &quoted[i]
+	}
+	for i := range messages {
+		if messages[i].ReplyToID != nil && messages[i].ReplyTo == nil {
+			messages[i].ReplyTo = byID[*messages[i].ReplyToID]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package filetype
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// SniffLength is the number of leading bytes Sniff looks at
+const SniffLength = 512
+
+// Generic types carry no information about the content
+const (
+	TypeBinary = "application/octet-stream"
+	TypeText   = "text/plain"
+)
+
+// signatures detects formats the standard sniffer does not know
+var signatures = []struct {
+	prefix   []byte
+	mimeType string
+}{
+	{[]byte("MZ"), "application/vnd.microsoft.portable-executable"},
+	{[]byte("\x7fELF"), "application/x-executable"},
+	{[]byte("\xcf\xfa\xed\xfe"), "application/x-mach-binary"},
+	{[]byte("7z\xbc\xaf\x27\x1c"), "application/x-7z-compressed"},
+}
+
+// aliases maps alternative names to the name returned by Sniff
+var aliases = map[string]string{
+	"image/jpg":                    "image/jpeg",
+	"image/pjpeg":                  "image/jpeg",
+	"audio/wav":                    "audio/wave",
+	"audio/x-wav":                  "audio/wave",
+	"audio/vnd.wave":               "audio/wave",
+	"audio/ogg":                    "application/ogg",
+	"audio/opus":                   "application/ogg",
+	"video/ogg":                    "application/ogg",
+	"audio/mp3":                    "audio/mpeg",
+	"application/x-zip-compressed": "application/zip",
+	"application/gzip":             "application/x-gzip",
+	"application/vnd.rar":          "application/x-rar-compressed",
+	"application/xml":              "text/xml",
+	"application/x-pdf":            "application/pdf",
+	"application/x-msdownload":     "application/vnd.microsoft.portable-executable",
+}
+
+// zipBased lists prefixes of formats stored as zip archives, the sniffer only sees the archive
+var zipBased = []string{
+	"application/vnd.openxmlformats-officedocument.",
+	"application/vnd.oasis.opendocument.",
+	"application/epub+zip",
+	"application/java-archive",
+	"application/vnd.android.package-archive",
+}
+
+// Sniff detects the MIME type of the content from its first bytes.
+// Unknown content is TypeBinary or, when it looks like text, TypeText.
+func Sniff(content []byte) string {
+	head := content[:min(len(content), SniffLength)]
+	for _, signature := range signatures {
+		if bytes.HasPrefix(head, signature.prefix) {
+			return signature.mimeType
+		}
+	}
+
+	detected, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return TypeBinary
+	}
+	return detected
+}
+
+// Matches checks that the content sniffed as the given type can be what the uploader declared.
+// Generic sniffed types match every declared type the sniffer cannot recognize.
+func Matches(declared string, sniffed string) bool {
+	declared, sniffed = canonical(declared), canonical(sniffed)
+	if declared == sniffed || declared == TypeBinary {
+		return true
+	}
+	if sniffed == "application/zip" && isZipBased(declared) {
+		return true
+	}
+	if sniffed == TypeBinary || sniffed == TypeText {
+		return !Recognizable(declared)
+	}
+	return false
+}
+
+// Recognizable checks if Sniff detects content of the type
+func Recognizable(mimeType string) bool {
+	mimeType = canonical(mimeType)
+	for _, signature := range signatures {
+		if signature.mimeType == mimeType {
+			return true
+		}
+	}
+	return recognized[mimeType]
+}
+
+func canonical(mimeType string) string {
+	mimeType = strings.ToLower(mimeType)
+	if alias, ok := aliases[mimeType]; ok {
+		return alias
+	}
+	return mimeType
+}
+
+func isZipBased(mimeType string) bool {
+	if strings.HasSuffix(mimeType, "+zip") {
+		return true
+	}
+	for _, prefix := range zipBased {
+		if strings.HasPrefix(mimeType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// recognized lists the specific types returned by http.DetectContentType
+var recognized = map[string]bool{
+	"text/html":                     true,
+	"text/xml":                      true,
+	"application/pdf":               true,
+	"application/postscript":        true,
+	"image/gif":                     true,
+	"image/png":                     true,
+	"image/jpeg":                    true,
+	"image/bmp":                     true,
+	"image/webp":                    true,
+	"image/x-icon":                  true,
+	"audio/basic":                   true,
+	"audio/aiff":                    true,
+	"audio/mpeg":                    true,
+	"application/ogg":               true,
+	"audio/midi":                    true,
+	"video/avi":                     true,
+	"audio/wave":                    true,
+	"video/mp4":                     true,
+	"video/webm":                    true,
+	"font/ttf":                      true,
+	"font/otf":                      true,
+	"font/collection":               true,
+	"font/woff":                     true,
+	"font/woff2":                    true,
+	"application/x-gzip":            true,
+	"application/zip":               true,
+	"application/x-rar-compressed":  true,
+	"application/wasm":              true,
+	"application/vnd.ms-fontobject": true,
+}
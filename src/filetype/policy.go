@@ -0,0 +1,46 @@
+package filetype
+
+import "strings"
+
+// Policy decides which types can be uploaded. Patterns are exact types or "type/*".
+type Policy struct {
+	// Allowed types, everything is allowed when empty
+	Allowed []string
+	// Denied types win over allowed ones
+	Denied []string
+}
+
+// ParsePatterns splits a comma separated list of patterns
+func ParsePatterns(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// Allows checks if files of the type can be uploaded
+func (p Policy) Allows(mimeType string) bool {
+	if matchesAny(p.Denied, mimeType) {
+		return false
+	}
+	return len(p.Allowed) == 0 || matchesAny(p.Allowed, mimeType)
+}
+
+// matchesAny compares types by their canonical names, wildcards also match the declared name
+func matchesAny(patterns []string, mimeType string) bool {
+	mimeType = strings.ToLower(mimeType)
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mimeType, prefix+"/") || strings.HasPrefix(canonical(mimeType), prefix+"/") {
+				return true
+			}
+		} else if canonical(pattern) == canonical(mimeType) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package hub
+
+import (
+	pb "alexchatapp/src/proto/chat"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultBufferSize is the number of events queued per stream before the slow consumer policy applies
+const DefaultBufferSize = 64
+
+// SlowConsumerPolicy defines what happens when a stream's buffer is full
+type SlowConsumerPolicy int
+
+const (
+	// PolicyDrop drops the event for the slow stream and keeps it connected
+	PolicyDrop SlowConsumerPolicy = iota
+	// PolicyDisconnect closes the slow stream so the client can reconnect and resync history
+	PolicyDisconnect
+)
+
+// ParsePolicy converts a config value ("drop" or "disconnect") into a policy
+func ParsePolicy(value string) (SlowConsumerPolicy, bool) {
+	switch value {
+	case "drop":
+		return PolicyDrop, true
+	case "disconnect":
+		return PolicyDisconnect, true
+	}
+	return PolicyDisconnect, false
+}
+
+// Subscriber is a single open stream of a user
+type Subscriber struct {
+	UserID uint
+
+	// channels followed by the stream, guarded by the hub lock
+	channels map[uint]struct{}
+
+	send      chan *pb.ServerEvent
+	done      chan struct{}
+	closeOnce sync.Once
+	dropped   atomic.Uint64
+}
+
+// Events returns the channel of events to be sent to the stream
+func (s *Subscriber) Events() <-chan *pb.ServerEvent {
+	return s.send
+}
+
+// Done is closed when the hub disconnects the subscriber
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+// Dropped returns the number of events dropped for this subscriber
+func (s *Subscriber) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+func (s *Subscriber) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// Hub tracks open streams per user and fans events out to them.
+// Streams also follow broadcast channels, so a channel post reaches the local followers
+// without the list of channel members.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uint]map[*Subscriber]struct{}
+	followers   map[uint]map[*Subscriber]struct{}
+	bufferSize  int
+	policy      SlowConsumerPolicy
+}
+
+// NewHub creates a new hub with bounded per-stream buffers
+func NewHub(bufferSize int, policy SlowConsumerPolicy) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &Hub{
+		subscribers: make(map[uint]map[*Subscriber]struct{}),
+		followers:   make(map[uint]map[*Subscriber]struct{}),
+		bufferSize:  bufferSize,
+		policy:      policy,
+	}
+}
+
+// Subscribe registers a new stream of the user
+func (h *Hub) Subscribe(user_id uint) *Subscriber {
+	subscriber := &Subscriber{
+		UserID:   user_id,
+		channels: make(map[uint]struct{}),
+		send:     make(chan *pb.ServerEvent, h.bufferSize),
+		done:     make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	streams, ok := h.subscribers[user_id]
+	if !ok {
+		streams = make(map[*Subscriber]struct{})
+		h.subscribers[user_id] = streams
+	}
+	streams[subscriber] = struct{}{}
+	return subscriber
+}
+
+// Unsubscribe removes the stream from the hub
+func (h *Hub) Unsubscribe(subscriber *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(subscriber)
+}
+
+// remove must be called with the write lock held
+func (h *Hub) remove(subscriber *Subscriber) {
+	subscriber.close()
+
+	for channel_id := range subscriber.channels {
+		h.unfollow(subscriber, channel_id)
+	}
+
+	streams, ok := h.subscribers[subscriber.UserID]
+	if !ok {
+		return
+	}
+	delete(streams, subscriber)
+	if len(streams) == 0 {
+		delete(h.subscribers, subscriber.UserID)
+	}
+}
+
+// FollowChannels makes the stream receive events of the channels
+func (h *Hub) FollowChannels(subscriber *Subscriber, channel_ids []uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// A stream removed by the slow consumer policy must not come back into the index
+	select {
+	case <-subscriber.done:
+		return
+	default:
+	}
+
+	for _, channel_id := range channel_ids {
+		h.follow(subscriber, channel_id)
+	}
+}
+
+// UnfollowChannels stops channel events of the channels for the stream
+func (h *Hub) UnfollowChannels(subscriber *Subscriber, channel_ids []uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, channel_id := range channel_ids {
+		h.unfollow(subscriber, channel_id)
+	}
+}
+
+// Follow makes every local stream of the user follow the channel
+func (h *Hub) Follow(user_id, channel_id uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscriber := range h.subscribers[user_id] {
+		h.follow(subscriber, channel_id)
+	}
+}
+
+// Unfollow stops channel events for every local stream of the user
+func (h *Hub) Unfollow(user_id, channel_id uint) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscriber := range h.subscribers[user_id] {
+		h.unfollow(subscriber, channel_id)
+	}
+}
+
+// follow must be called with the write lock held
+func (h *Hub) follow(subscriber *Subscriber, channel_id uint) {
+	followers, ok := h.followers[channel_id]
+	if !ok {
+		followers = make(map[*Subscriber]struct{})
+		h.followers[channel_id] = followers
+	}
+	followers[subscriber] = struct{}{}
+	subscriber.channels[channel_id] = struct{}{}
+}
+
+// unfollow must be called with the write lock held
+func (h *Hub) unfollow(subscriber *Subscriber, channel_id uint) {
+	delete(subscriber.channels, channel_id)
+
+	followers, ok := h.followers[channel_id]
+	if !ok {
+		return
+	}
+	delete(followers, subscriber)
+	if len(followers) == 0 {
+		delete(h.followers, channel_id)
+	}
+}
+
+// IsOnline checks if the user has at least one open stream
+func (h *Hub) IsOnline(user_id uint) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subscribers[user_id]) > 0
+}
+
+// Publish delivers the event to every open stream of the given users.
+// It never blocks: full buffers are handled according to the hub policy.
+func (h *Hub) Publish(user_ids []uint, event *pb.ServerEvent) {
+	var slow []*Subscriber
+
+	h.mu.RLock()
+	for _, user_id := range user_ids {
+		slow = h.deliver(h.subscribers[user_id], event, slow)
+	}
+	h.mu.RUnlock()
+
+	h.disconnect(slow)
+}
+
+// PublishChannel delivers the event to every local stream following the channel, like Publish
+func (h *Hub) PublishChannel(channel_id uint, event *pb.ServerEvent) {
+	h.mu.RLock()
+	slow := h.deliver(h.followers[channel_id], event, nil)
+	h.mu.RUnlock()
+
+	h.disconnect(slow)
+}
+
+// deliver must be called with the read lock held, it returns slow with the streams to disconnect appended
+func (h *Hub) deliver(subscribers map[*Subscriber]struct{}, event *pb.ServerEvent, slow []*Subscriber) []*Subscriber {
+	for subscriber := range subscribers {
+		select {
+		case subscriber.send <- event:
+		default:
+			subscriber.dropped.Add(1)
+			if h.policy == PolicyDisconnect {
+				slow = append(slow, subscriber)
+			}
+		}
+	}
+	return slow
+}
+
+func (h *Hub) disconnect(slow []*Subscriber) {
+	if len(slow) == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subscriber := range slow {
+		log.Printf("Disconnecting slow stream of user %d", subscriber.UserID)
+		h.remove(subscriber)
+	}
+}
@@ -0,0 +1,161 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels rejects images that would take too much memory to decode
+const MaxPixels = 50_000_000
+
+// Thumbnail names, thumbnails fit into a square of the given size
+const (
+	ThumbnailSmall  = "small"
+	ThumbnailMedium = "medium"
+)
+
+// ThumbnailNames lists every generated thumbnail, smallest first
+var ThumbnailNames = []string{ThumbnailSmall, ThumbnailMedium}
+
+var thumbnailSizes = map[string]int{
+	ThumbnailSmall:  160,
+	ThumbnailMedium: 640,
+}
+
+// thumbnailQuality is the JPEG quality of thumbnails
+const thumbnailQuality = 80
+
+// orientedQuality is the JPEG quality of photos rotated according to their EXIF orientation
+const orientedQuality = 90
+
+// ErrUnsupportedFormat is returned for content that is not a JPEG, PNG, GIF or WebP image
+var ErrUnsupportedFormat = errors.New("image must be JPEG, PNG, GIF or WebP")
+
+var mimeTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+	"webp": "image/webp",
+}
+
+// Image is a validated image without metadata
+type Image struct {
+	// MimeType is detected from the content
+	MimeType string
+	Width    int
+	Height   int
+	// Data is the image without EXIF, XMP and text metadata
+	Data       []byte
+	Thumbnails []Thumbnail
+}
+
+// Thumbnail is a downscaled JPEG copy of an image
+type Thumbnail struct {
+	Name   string
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// Check reads only the header of the image and returns the error Process would return for its format
+// or dimensions, so content that cannot be processed is rejected before it is read into memory
+func Check(r io.Reader) error {
+	_, _, err := checkHeader(r)
+	return err
+}
+
+// checkHeader returns the format and MIME type of a supported image that is not too large to decode
+func checkHeader(r io.Reader) (string, string, error) {
+	config, format, err := image.DecodeConfig(r)
+	if errors.Is(err, image.ErrFormat) {
+		return "", "", ErrUnsupportedFormat
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("image is corrupt: %w", err)
+	}
+	mimeType, ok := mimeTypes[format]
+	if !ok {
+		return "", "", ErrUnsupportedFormat
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return "", "", errors.New("image is empty")
+	}
+	if config.Width*config.Height > MaxPixels {
+		return "", "", fmt.Errorf("image must not exceed %d pixels", MaxPixels)
+	}
+	return format, mimeType, nil
+}
+
+// Process validates the image, removes its metadata and generates thumbnails.
+// Photos with an EXIF orientation are rotated, since the orientation is removed with the rest of the metadata.
+func Process(data []byte) (*Image, error) {
+	format, mimeType, err := checkHeader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("image is corrupt: %w", err)
+	}
+
+	stripped, orientation, err := stripMetadata(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("image is corrupt: %w", err)
+	}
+
+	if orientation > 1 && orientation <= 8 {
+		decoded = orient(decoded, orientation)
+
+		var buffer bytes.Buffer
+		if format == "jpeg" {
+			err = jpeg.Encode(&buffer, decoded, &jpeg.Options{Quality: orientedQuality})
+		} else {
+			// WebP cannot be encoded in pure Go, PNG keeps every pixel
+			err = png.Encode(&buffer, decoded)
+			mimeType = mimeTypes["png"]
+		}
+		if err != nil {
+			return nil, err
+		}
+		stripped = buffer.Bytes()
+	}
+
+	bounds := decoded.Bounds()
+	result := &Image{
+		MimeType: mimeType,
+		Width:    bounds.Dx(),
+		Height:   bounds.Dy(),
+		Data:     stripped,
+	}
+
+	// Every thumbnail is scaled from the next larger one, which is much cheaper than the original
+	result.Thumbnails = make([]Thumbnail, len(ThumbnailNames))
+	source := decoded
+	for i := len(ThumbnailNames) - 1; i >= 0; i-- {
+		name := ThumbnailNames[i]
+		scaled := scale(source, thumbnailSizes[name])
+
+		var buffer bytes.Buffer
+		if err := jpeg.Encode(&buffer, flatten(scaled), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, err
+		}
+		result.Thumbnails[i] = Thumbnail{
+			Name:   name,
+			Width:  scaled.Bounds().Dx(),
+			Height: scaled.Bounds().Dy(),
+			Data:   buffer.Bytes(),
+		}
+		source = scaled
+	}
+
+	return result, nil
+}
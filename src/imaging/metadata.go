@@ -0,0 +1,234 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var errTruncated = errors.New("unexpected end of data")
+
+// exifHeader starts the EXIF segment of JPEG files
+var exifHeader = []byte("Exif\x00\x00")
+
+// stripMetadata removes EXIF, XMP, comments and text chunks from the encoded image.
+// It returns the EXIF orientation, 1 when the image has none. GIF has no EXIF and is kept as is.
+func stripMetadata(format string, data []byte) ([]byte, int, error) {
+	switch format {
+	case "jpeg":
+		return stripJPEG(data)
+	case "png":
+		return stripPNG(data)
+	case "webp":
+		return stripWebP(data)
+	}
+	return data, 1, nil
+}
+
+// stripJPEG keeps the JFIF header, the ICC color profile and the Adobe color transform,
+// every other application segment and comment is dropped together with data after the image
+func stripJPEG(data []byte) ([]byte, int, error) {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, 0, errors.New("missing JPEG header")
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	orientation := 1
+	scanned := false
+
+	i := 2
+	for {
+		if i >= len(data) && scanned {
+			// Some encoders omit the end marker
+			out = append(out, 0xFF, 0xD9)
+			return out, orientation, nil
+		}
+		// Markers may be padded with any number of 0xFF
+		for i+1 < len(data) && data[i] == 0xFF && data[i+1] == 0xFF {
+			i++
+		}
+		if i+1 >= len(data) || data[i] != 0xFF {
+			return nil, 0, errTruncated
+		}
+		marker := data[i+1]
+
+		if marker == 0xD9 {
+			out = append(out, 0xFF, 0xD9)
+			return out, orientation, nil
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out = append(out, 0xFF, marker)
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, 0, errTruncated
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end > len(data) || end < i+4 {
+			return nil, 0, errTruncated
+		}
+		payload := data[i+4 : end]
+
+		keep := true
+		switch {
+		case marker == 0xE1:
+			if bytes.HasPrefix(payload, exifHeader) {
+				orientation = exifOrientation(payload[len(exifHeader):])
+			}
+			keep = false
+		case marker == 0xE2:
+			keep = bytes.HasPrefix(payload, []byte("ICC_PROFILE\x00"))
+		case marker >= 0xE3 && marker <= 0xEF:
+			keep = marker == 0xEE
+		case marker == 0xFE:
+			keep = false
+		}
+		if keep {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+
+		if marker == 0xDA {
+			scanned = true
+			// Entropy-coded data runs until the next marker other than stuffing and restarts
+			start := i
+			for i < len(data) && (i+1 == len(data) || data[i] != 0xFF || data[i+1] == 0x00 || (data[i+1] >= 0xD0 && data[i+1] <= 0xD7)) {
+				i++
+			}
+			out = append(out, data[start:i]...)
+		}
+	}
+}
+
+// pngSignature starts every PNG file
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks hold EXIF, text (including XMP) and the modification time
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNG drops metadata chunks, chunks are copied together with their checksums
+func stripPNG(data []byte) ([]byte, int, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, 0, errors.New("missing PNG signature")
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	orientation := 1
+
+	i := len(pngSignature)
+	for {
+		if i+8 > len(data) {
+			return nil, 0, errTruncated
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if length < 0 || end > len(data) || end < i {
+			return nil, 0, errTruncated
+		}
+
+		if chunkType == "eXIf" {
+			orientation = exifOrientation(data[i+8 : i+8+length])
+		}
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+
+		if chunkType == "IEND" {
+			return out, orientation, nil
+		}
+	}
+}
+
+// VP8X flags announcing EXIF and XMP chunks
+const (
+	webpFlagEXIF = 0x08
+	webpFlagXMP  = 0x04
+)
+
+// stripWebP drops the EXIF and XMP chunks of the RIFF container and clears their flags
+func stripWebP(data []byte) ([]byte, int, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, 0, errors.New("missing WebP header")
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	orientation := 1
+
+	riffEnd := min(len(data), 8+int(binary.LittleEndian.Uint32(data[4:8])))
+	i := 12
+	for i+8 <= riffEnd {
+		fourCC := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > riffEnd || end < i {
+			return nil, 0, errTruncated
+		}
+
+		switch fourCC {
+		case "EXIF":
+			payload := bytes.TrimPrefix(data[i+8:i+8+size], exifHeader)
+			orientation = exifOrientation(payload)
+		case "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[i:end]...)
+			if size > 0 {
+				out[start+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, orientation, nil
+}
+
+// exifOrientationTag is the TIFF tag of the orientation in the first image directory
+const exifOrientationTag = 0x0112
+
+// exifOrientation reads the orientation from EXIF data in TIFF format, broken data counts as no orientation
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for entry := offset + 2; entry+12 <= len(tiff) && count > 0; entry, count = entry+12, count-1 {
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
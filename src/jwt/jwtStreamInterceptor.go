@@ -0,0 +1,89 @@
+package jwt
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrTokenExpired is returned to streams that outlive the token they were opened with
+var ErrTokenExpired = status.Error(codes.Unauthenticated, "Token expired, reconnect with a new token")
+
+// authenticatedStream wraps grpc.ServerStream to carry the authenticated context
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context with user data added by the interceptor
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg fails once the token has expired
+func (s *authenticatedStream) RecvMsg(m interface{}) error {
+	if err := s.tokenError(); err != nil {
+		return err
+	}
+	return s.ServerStream.RecvMsg(m)
+}
+
+// SendMsg fails once the token has expired
+func (s *authenticatedStream) SendMsg(m interface{}) error {
+	if err := s.tokenError(); err != nil {
+		return err
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *authenticatedStream) tokenError() error {
+	if s.ctx.Err() != nil && context.Cause(s.ctx) == ErrTokenExpired {
+		return ErrTokenExpired
+	}
+	return nil
+}
+
+// JWTStreamInterceptor creates a JWT validation interceptor for streaming methods.
+// The stream context is cancelled with ErrTokenExpired as its cause when the token expires,
+// so handlers waiting on Context().Done() can close the stream.
+func JWTStreamInterceptor() grpc.StreamServerInterceptor {
+	jwtKey := loadJwtKey()
+
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		log.Println("Invoking stream method: " + info.FullMethod)
+
+		if isPublicMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctx := stream.Context()
+
+		// Extract JWT token from metadata
+		token, err := extractTokenFromMetadata(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Validate JWT token
+		username, user_id, expiration, err := jwtKey.ValidateTokenWithExpiration(token)
+		if err != nil {
+			log.Printf("JWT validation failed: %v", err)
+			return status.Error(codes.Unauthenticated, "Invalid or expired token")
+		}
+
+		ctx, cancel := context.WithDeadlineCause(ctx, expiration, ErrTokenExpired)
+		defer cancel()
+
+		ctx = context.WithValue(ctx, UsernameKey, username)
+		ctx = context.WithValue(ctx, UserIdKey, user_id)
+
+		err = handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+		if err == nil && context.Cause(ctx) == ErrTokenExpired {
+			return ErrTokenExpired
+		}
+		return err
+	}
+}
@@ -0,0 +1,109 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "test-secret"
+
+var streamInfo = &grpc.StreamServerInfo{FullMethod: "/alexchatapp.ChatService/ChatStream"}
+
+// signToken signs the claims with the test secret, a zero expiration leaves out exp
+func signToken(t *testing.T, user_id uint64, expiration time.Time) string {
+	t.Helper()
+	claims := jwt.MapClaims{"username": "alice", "user_id": user_id}
+	if !expiration.IsZero() {
+		claims["exp"] = expiration.Unix()
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func authorizedContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+// testStream is a server stream of a client that sends and receives nothing
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context    { return s.ctx }
+func (s *testStream) SendMsg(m interface{}) error { return nil }
+func (s *testStream) RecvMsg(m interface{}) error { return nil }
+
+func TestStreamEndsWhenTokenExpires(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecret)
+	interceptor := JWTStreamInterceptor()
+
+	// The token expires while the handler waits for the client
+	token := signToken(t, 7, time.Now().Add(1500*time.Millisecond))
+	stream := &testStream{ctx: authorizedContext(token)}
+
+	var recvErr, sendErr error
+	started := time.Now()
+	err := interceptor(nil, stream, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+		if id, ok := GetUserIdFromContext(stream.Context()); !ok || id != 7 {
+			t.Errorf("user id in stream context is %d, %v", id, ok)
+		}
+		// Handlers wait on the context next to receiving, like ChatStream
+		<-stream.Context().Done()
+		recvErr = stream.RecvMsg(nil)
+		sendErr = stream.SendMsg(nil)
+		return nil
+	})
+
+	if time.Since(started) > 5*time.Second {
+		t.Fatalf("stream ended %v after it started", time.Since(started))
+	}
+	if !errors.Is(err, ErrTokenExpired) || !errors.Is(recvErr, ErrTokenExpired) || !errors.Is(sendErr, ErrTokenExpired) {
+		t.Fatalf("stream ended with %v, RecvMsg %v, SendMsg %v, want ErrTokenExpired", err, recvErr, sendErr)
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("stream ended with code %v", status.Code(err))
+	}
+}
+
+func TestStreamReconnectsWithRefreshedToken(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecret)
+	interceptor := JWTStreamInterceptor()
+	handler := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+
+	expired := signToken(t, 7, time.Now().Add(-time.Minute))
+	err := interceptor(nil, &testStream{ctx: authorizedContext(expired)}, streamInfo, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("stream with an expired token returned %v", err)
+	}
+
+	refreshed := signToken(t, 7, time.Now().Add(time.Hour))
+	if err := interceptor(nil, &testStream{ctx: authorizedContext(refreshed)}, streamInfo, handler); err != nil {
+		t.Fatalf("stream with a refreshed token returned %v", err)
+	}
+}
+
+func TestStreamRejectsTokenWithoutExpiration(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecret)
+	interceptor := JWTStreamInterceptor()
+
+	token := signToken(t, 7, time.Time{})
+	err := interceptor(nil, &testStream{ctx: authorizedContext(token)}, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+		t.Error("handler called for a token without exp")
+		return nil
+	})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("stream with a token without exp returned %v", err)
+	}
+}
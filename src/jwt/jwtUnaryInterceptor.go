@@ -23,15 +23,7 @@ const (
 
 // JWTUnaryInterceptor creates a production-ready JWT validation interceptor
 func JWTUnaryInterceptor() grpc.UnaryServerInterceptor {
-	// Initialize JWT key from environment
-	secretKey := os.Getenv("SECRET_KEY")
-	if secretKey == "" {
-		log.Fatal("SECRET_KEY environment variable is required")
-	}
-
-	jwtKey := &JwtKey{
-		SecretKey: []byte(secretKey),
-	}
+	jwtKey := loadJwtKey()
 
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		log.Println("Invoking method: " + info.FullMethod)
@@ -64,6 +56,18 @@ func JWTUnaryInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// loadJwtKey initializes JWT key from environment
+func loadJwtKey() *JwtKey {
+	secretKey := os.Getenv("SECRET_KEY")
+	if secretKey == "" {
+		log.Fatal("SECRET_KEY environment variable is required")
+	}
+
+	return &JwtKey{
+		SecretKey: []byte(secretKey),
+	}
+}
+
 // extractTokenFromMetadata extracts and validates JWT token from gRPC metadata
 func extractTokenFromMetadata(ctx context.Context) (string, error) {
 	meta, ok := metadata.FromIncomingContext(ctx)
@@ -0,0 +1,41 @@
+package jwt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryRequiresExpiration(t *testing.T) {
+	t.Setenv("SECRET_KEY", testSecret)
+	interceptor := JWTUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/alexchatapp.ChatService/GetChats"}
+
+	var calls int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return nil, nil
+	}
+
+	valid := signToken(t, 7, time.Now().Add(time.Hour))
+	if _, err := interceptor(authorizedContext(valid), nil, info, handler); err != nil {
+		t.Fatalf("call with a valid token returned %v", err)
+	}
+
+	for name, token := range map[string]string{
+		"expired":     signToken(t, 7, time.Now().Add(-time.Minute)),
+		"without exp": signToken(t, 7, time.Time{}),
+	} {
+		_, err := interceptor(authorizedContext(token), nil, info, handler)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("call with a token %s returned %v", name, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("handler called %d times, want 1", calls)
+	}
+}
@@ -23,6 +23,13 @@ func (j *JwtKey) GenerateToken(username string, userId uint64) (string, error) {
 }
 
 func (j *JwtKey) ValidateToken(tokenString string) (string, uint64, error) {
+	username, user_id, _, err := j.ValidateTokenWithExpiration(tokenString)
+	return username, user_id, err
+}
+
+// ValidateTokenWithExpiration validates the token and also returns its expiration time,
+// long-lived streams use it to know when the caller must re-authenticate
+func (j *JwtKey) ValidateTokenWithExpiration(tokenString string) (string, uint64, time.Time, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -31,23 +38,28 @@ func (j *JwtKey) ValidateToken(tokenString string) (string, uint64, error) {
 	})
 
 	if err != nil {
-		return "", 0, err
+		return "", 0, time.Time{}, err
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
 		username, ok := claims["username"].(string)
 		if !ok {
-			return "", 0, errors.New("invalid claims")
+			return "", 0, time.Time{}, errors.New("invalid claims")
 		}
 		userIdFloat, ok := claims["user_id"].(float64)
 		if !ok {
-			return "", 0, errors.New("invalid claims")
+			return "", 0, time.Time{}, errors.New("invalid claims")
 		}
 
 		user_id := uint64(userIdFloat)
 
-		return username, user_id, nil
+		expiration, err := claims.GetExpirationTime()
+		if err != nil || expiration == nil {
+			return "", 0, time.Time{}, errors.New("invalid claims")
+		}
+
+		return username, user_id, expiration.Time, nil
 	}
 
-	return "", 0, errors.New("invalid token")
+	return "", 0, time.Time{}, errors.New("invalid token")
 }
@@ -0,0 +1,208 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+// Limits of the preview fields, longer values are cut
+const (
+	MaxTitleLength       = 300
+	MaxDescriptionLength = 1000
+	MaxURLLength         = 2048
+)
+
+// maxRedirects is the number of redirects followed to reach the page
+const maxRedirects = 5
+
+var (
+	// ErrBlockedAddress is returned when the link leads to a private, loopback or otherwise internal address
+	ErrBlockedAddress = errors.New("address is not public")
+	// ErrNotHTML is returned for links to content other than web pages
+	ErrNotHTML = errors.New("content is not an HTML page")
+)
+
+// Preview describes the page behind a link, taken from its Open Graph tags
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	// ImageURL is absolute, clients load the image themselves
+	ImageURL string
+}
+
+// Fetcher loads previews of links
+type Fetcher interface {
+	Fetch(ctx context.Context, link string) (*Preview, error)
+}
+
+// Config limits what the HTTP fetcher loads
+type Config struct {
+	// Timeout limits a whole fetch, redirects included
+	Timeout time.Duration
+	// MaxSize is the number of bytes of a page read looking for its tags
+	MaxSize int64
+	// Allowed are ranges reachable despite not being public, such as the loopback address of httptest servers.
+	// Servers leave it empty.
+	Allowed []netip.Prefix
+}
+
+// HTTPFetcher loads pages over HTTP and HTTPS, connecting only to public addresses.
+// The address is checked when connecting, so links cannot reach internal services by DNS names or redirects.
+type HTTPFetcher struct {
+	client  *http.Client
+	maxSize int64
+	allowed []netip.Prefix
+}
+
+// NewHTTPFetcher creates a fetcher with the limits of the config
+func NewHTTPFetcher(config Config) *HTTPFetcher {
+	f := &HTTPFetcher{maxSize: config.MaxSize, allowed: config.Allowed}
+
+	dialer := &net.Dialer{Timeout: config.Timeout, Control: f.checkAddress}
+	transport := &http.Transport{
+		// Proxies would connect on behalf of the fetcher, bypassing the address check
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   config.Timeout,
+		ResponseHeaderTimeout: config.Timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+	f.client = &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("too many redirects")
+			}
+			return checkURL(req.URL)
+		},
+	}
+	return f
+}
+
+// Fetch loads the page behind the link and reads its Open Graph tags,
+// falling back to the title and description of the page
+func (f *HTTPFetcher) Fetch(ctx context.Context, link string) (*Preview, error) {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkURL(parsed); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "AlexChatApp-LinkPreview/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("page returned %s", resp.Status)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, ErrNotHTML
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, f.maxSize), contentType)
+	if err != nil {
+		return nil, err
+	}
+	preview := parsePage(body, resp.Request.URL)
+	preview.URL = link
+	return preview, nil
+}
+
+// checkURL accepts absolute http and https links without credentials
+func checkURL(link *url.URL) error {
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return errors.New("link must use http or https")
+	}
+	if link.Hostname() == "" {
+		return errors.New("link has no host")
+	}
+	if link.User != nil {
+		return errors.New("link must not contain credentials")
+	}
+	if len(link.String()) > MaxURLLength {
+		return errors.New("link is too long")
+	}
+	return nil
+}
+
+// checkAddress runs before every connection, including those of redirects, with the resolved address
+func (f *HTTPFetcher) checkAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range f.allowed {
+		if prefix.Contains(addr) {
+			return nil
+		}
+	}
+	if !IsPublic(addr) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+// nonPublic lists special purpose ranges that the netip checks do not cover
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	// NAT64 and 6to4 embed IPv4 addresses, which may be internal ones
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("2002::/16"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// IsPublic checks if the address is reachable on the internet,
+// private, loopback, link-local and other special purpose addresses are not
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublic {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
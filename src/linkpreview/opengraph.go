@@ -0,0 +1,115 @@
+package linkpreview
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// parsePage reads the tags of the page head, base resolves relative image links.
+// Reading stops at the body, so a page cut by the size limit still has its head.
+func parsePage(r io.Reader, base *url.URL) *Preview {
+	var (
+		preview                    Preview
+		pageTitle, pageDescription string
+		inTitle                    bool
+	)
+
+	tokenizer := html.NewTokenizer(r)
+loop:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			break loop
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttributes := tokenizer.TagName()
+			switch string(name) {
+			case "body":
+				break loop
+			case "title":
+				inTitle = pageTitle == ""
+			case "meta":
+				if !hasAttributes {
+					continue
+				}
+				key, content := metaAttributes(tokenizer)
+				switch key {
+				case "og:title":
+					preview.Title = content
+				case "og:description":
+					preview.Description = content
+				case "og:image", "og:image:url", "og:image:secure_url":
+					if preview.ImageURL == "" {
+						preview.ImageURL = resolveImage(base, content)
+					}
+				case "description":
+					pageDescription = content
+				}
+			}
+		case html.TextToken:
+			if inTitle {
+				pageTitle += string(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "head":
+				break loop
+			case "title":
+				inTitle = false
+			}
+		}
+	}
+
+	if preview.Title == "" {
+		preview.Title = pageTitle
+	}
+	if preview.Description == "" {
+		preview.Description = pageDescription
+	}
+	preview.Title = clean(preview.Title, MaxTitleLength)
+	preview.Description = clean(preview.Description, MaxDescriptionLength)
+	return &preview
+}
+
+// metaAttributes returns the property or name of a meta tag together with its content
+func metaAttributes(tokenizer *html.Tokenizer) (string, string) {
+	var key, content string
+	for {
+		name, value, more := tokenizer.TagAttr()
+		switch string(name) {
+		case "property":
+			key = strings.ToLower(string(value))
+		case "name":
+			if key == "" {
+				key = strings.ToLower(string(value))
+			}
+		case "content":
+			content = string(value)
+		}
+		if !more {
+			return key, content
+		}
+	}
+}
+
+// resolveImage makes the image link absolute, links that are not http or https are dropped
+func resolveImage(base *url.URL, link string) string {
+	image, err := base.Parse(strings.TrimSpace(link))
+	if err != nil || checkURL(image) != nil {
+		return ""
+	}
+	return image.String()
+}
+
+// clean collapses whitespace and cuts the text to limit characters
+func clean(text string, limit int) string {
+	text = strings.Join(strings.Fields(strings.ToValidUTF8(text, "")), " ")
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	return string([]rune(text)[:limit-1]) + "…"
+}
@@ -0,0 +1,826 @@
+package alexchatapp
+
+import (
+	"alexchatapp/src/blob"
+	"alexchatapp/src/data"
+	"alexchatapp/src/filetype"
+	"alexchatapp/src/imaging"
+	"alexchatapp/src/models"
+	pb "alexchatapp/src/proto/media"
+	"alexchatapp/src/scan"
+	"alexchatapp/src/utils"
+	"alexchatapp/src/voice"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxChunkSize keeps upload messages well below the 4 MB gRPC limit
+	maxChunkSize = 1 << 20
+	// downloadChunkSize is the size of chunks sent by Download
+	downloadChunkSize = 256 << 10
+	// gcBatchSize is the number of unused blobs deleted per query
+	gcBatchSize = 100
+	// scanBatchSize is the number of unscanned contents picked up per sweep
+	scanBatchSize = 100
+)
+
+type MediaServer struct {
+	pb.UnimplementedMediaServiceServer
+	media_repo *data.MediaRepository
+	store      blob.Store
+	staging    *blob.Staging
+	scanner    scan.Scanner
+	config     MediaConfig
+	// scanned is told about the messages whose attachment was scanned
+	scanned func(messages []models.Message, scan_status string)
+
+	// uploads receiving chunks on this node, a second stream for the same upload is rejected.
+	// CompleteMedia catches uploads completed on another node.
+	active sync.Map
+	// hashes of content being scanned right now
+	scanning sync.Map
+}
+
+// NewMediaServer creates a new media server instance.
+// Completed content is scanned in the background, scanned is called for the messages that attach it.
+func NewMediaServer(media_repo *data.MediaRepository, store blob.Store, staging *blob.Staging, scanner scan.Scanner, config MediaConfig, scanned func(messages []models.Message, scan_status string)) *MediaServer {
+	return &MediaServer{
+		media_repo: media_repo,
+		store:      store,
+		staging:    staging,
+		scanner:    scanner,
+		config:     config,
+		scanned:    scanned,
+	}
+}
+
+// StartUpload registers an upload or finds where an unfinished one stopped.
+// Content that is already stored is not uploaded again, the new media is complete right away.
+func (s *MediaServer) StartUpload(ctx context.Context, req *pb.StartUploadRequest) (*pb.StartUploadResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := utils.ValidateContentHash(req.Sha256); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := utils.ValidateMimeType(req.MimeType); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.Size <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "size must be positive")
+	}
+	if req.Size > s.config.MaxSize {
+		return nil, status.Errorf(codes.InvalidArgument, "size must not exceed %d bytes", s.config.MaxSize)
+	}
+	if !s.config.Types.Allows(req.MimeType) {
+		return nil, status.Errorf(codes.InvalidArgument, "files of type %s are not allowed", req.MimeType)
+	}
+
+	media, err := s.media_repo.FindOwnMedia(userID, req.Sha256, req.Size)
+	if err == nil {
+		offset := media.UploadSize
+		if media.CompletedAt == nil {
+			offset, err = s.staging.Offset(media.ID)
+			if err != nil {
+				log.Printf("Staging offset error: %v", err)
+				return nil, status.Error(codes.Internal, "failed to start upload")
+			}
+		}
+		return &pb.StartUploadResponse{Media: mediaToProto(media), Offset: offset}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("FindOwnMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to start upload")
+	}
+
+	// Checked again when the upload completes, this only saves uploading what cannot be stored
+	if s.config.UserQuota > 0 {
+		used, err := s.media_repo.GetStorageUsage(userID)
+		if err != nil {
+			log.Printf("GetStorageUsage error: %v", err)
+			return nil, status.Error(codes.Internal, "failed to start upload")
+		}
+		if used+req.Size > s.config.UserQuota {
+			return nil, status.Error(codes.ResourceExhausted, data.ErrQuotaExceeded.Error())
+		}
+	}
+
+	media = &models.Media{
+		OwnerID:    userID,
+		UploadHash: req.Sha256,
+		UploadSize: req.Size,
+		Hash:       req.Sha256,
+		Size:       req.Size,
+		MimeType:   req.MimeType,
+	}
+	reused, err := s.media_repo.ReuseBlob(media, s.config.UserQuota)
+	if errors.Is(err, data.ErrQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		log.Printf("ReuseBlob error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to start upload")
+	}
+	if reused {
+		if media.ScanStatus == models.ScanPending {
+			s.startScan(media.Hash)
+		}
+		return &pb.StartUploadResponse{Media: mediaToProto(media), Offset: media.UploadSize}, nil
+	}
+
+	if err := s.media_repo.CreateMedia(media); err != nil {
+		log.Printf("CreateMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to start upload")
+	}
+	return &pb.StartUploadResponse{Media: mediaToProto(media), Offset: 0}, nil
+}
+
+// Upload receives chunks of a started upload and completes it once every byte arrived.
+// A stream closed early keeps the received bytes, the upload continues from the returned offset.
+func (s *MediaServer) Upload(stream pb.MediaService_UploadServer) error {
+	userID, err := authenticatedUserID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	chunk, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "upload has no chunks")
+	}
+	if err != nil {
+		return err
+	}
+
+	media, err := s.findMedia(chunk.MediaId)
+	if err != nil {
+		return err
+	}
+	if media.OwnerID != userID {
+		return status.Error(codes.NotFound, "media not found")
+	}
+	if media.CompletedAt != nil {
+		return stream.SendAndClose(&pb.UploadResponse{Media: mediaToProto(media), Offset: media.UploadSize})
+	}
+
+	if _, busy := s.active.LoadOrStore(media.ID, struct{}{}); busy {
+		return status.Error(codes.Aborted, "upload is already in progress on another stream")
+	}
+	defer s.active.Delete(media.ID)
+
+	offset, err := s.receiveChunks(stream, media, chunk)
+	if err != nil {
+		return err
+	}
+
+	if offset == media.UploadSize {
+		if err := s.completeUpload(stream.Context(), media); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(&pb.UploadResponse{Media: mediaToProto(media), Offset: offset})
+}
+
+// StoreInline stores content sent inline in a message as completed media of the owner, declared as mime_type.
+// It passes the same checks and processing as an upload, content the owner already stored is not stored again.
+func (s *MediaServer) StoreInline(ctx context.Context, owner_id uint, content []byte, mime_type string) (*models.Media, error) {
+	size := int64(len(content))
+	if size == 0 {
+		return nil, status.Error(codes.InvalidArgument, "content is empty")
+	}
+	if size > s.config.MaxSize {
+		return nil, status.Errorf(codes.InvalidArgument, "size must not exceed %d bytes", s.config.MaxSize)
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	media, err := s.media_repo.FindOwnMedia(owner_id, hash, size)
+	if err == nil && media.CompletedAt != nil {
+		return media, nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("FindOwnMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store media")
+	}
+
+	media = &models.Media{
+		OwnerID:    owner_id,
+		UploadHash: hash,
+		UploadSize: size,
+		Hash:       hash,
+		Size:       size,
+		MimeType:   mime_type,
+	}
+	reused, err := s.media_repo.ReuseBlob(media, s.config.UserQuota)
+	if errors.Is(err, data.ErrQuotaExceeded) {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		log.Printf("ReuseBlob error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store media")
+	}
+	if reused {
+		if media.ScanStatus == models.ScanPending {
+			s.startScan(media.Hash)
+		}
+		return media, nil
+	}
+
+	if err := s.media_repo.CreateMedia(media); err != nil {
+		log.Printf("CreateMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store media")
+	}
+	file, _, err := s.staging.Append(media.ID)
+	if err != nil {
+		log.Printf("Staging append error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store media")
+	}
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Printf("Staging write error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store media")
+	}
+
+	if err := s.completeUpload(ctx, media); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// receiveChunks appends chunks to the staged upload until the client closes the stream
+func (s *MediaServer) receiveChunks(stream pb.MediaService_UploadServer, media *models.Media, chunk *pb.UploadChunk) (int64, error) {
+	file, offset, err := s.staging.Append(media.ID)
+	if err != nil {
+		log.Printf("Staging append error: %v", err)
+		return 0, status.Error(codes.Internal, "failed to store upload")
+	}
+	defer file.Close()
+
+	if chunk.Offset != offset {
+		return 0, status.Errorf(codes.FailedPrecondition, "upload continues at offset %d", offset)
+	}
+
+	for {
+		if len(chunk.Data) > maxChunkSize {
+			return 0, status.Errorf(codes.InvalidArgument, "chunk must not exceed %d bytes", maxChunkSize)
+		}
+		if offset+int64(len(chunk.Data)) > media.UploadSize {
+			return 0, status.Error(codes.InvalidArgument, "upload exceeds the declared size")
+		}
+
+		if _, err := file.Write(chunk.Data); err != nil {
+			log.Printf("Staging write error: %v", err)
+			return 0, status.Error(codes.Internal, "failed to store upload")
+		}
+		offset += int64(len(chunk.Data))
+
+		chunk, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		log.Printf("Staging close error: %v", err)
+		return 0, status.Error(codes.Internal, "failed to store upload")
+	}
+	return offset, nil
+}
+
+// completeUpload verifies the staged content and moves it to the store.
+// The sniffed type must match the declared one, images and voice recordings are processed by their type.
+// Images are validated and stored without metadata, next to their thumbnails.
+func (s *MediaServer) completeUpload(ctx context.Context, media *models.Media) error {
+	hash, err := s.staging.Hash(media.ID)
+	if err != nil {
+		log.Printf("Staging hash error: %v", err)
+		return status.Error(codes.Internal, "failed to store upload")
+	}
+	if hash != media.UploadHash {
+		// The content is broken somewhere, the upload starts over
+		if err := s.staging.Remove(media.ID); err != nil {
+			log.Printf("Staging remove error: %v", err)
+		}
+		return status.Error(codes.DataLoss, "uploaded content does not match sha256, upload it again from offset 0")
+	}
+
+	if err := s.checkType(media); err != nil {
+		return err
+	}
+
+	var image *imaging.Image
+	switch {
+	case strings.HasPrefix(media.MimeType, "image/"):
+		image, err = s.processImage(media)
+	case strings.HasPrefix(media.MimeType, "audio/"):
+		err = s.processAudio(media)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.media_repo.CompleteMedia(media, s.config.UserQuota, func() error {
+		if image != nil {
+			return s.storeImage(ctx, media.Hash, image)
+		}
+
+		exists, err := s.store.Exists(ctx, media.Hash)
+		if err != nil || exists {
+			return err
+		}
+
+		file, err := s.staging.Open(media.ID)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return s.store.Put(ctx, media.Hash, file, media.Size)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, "upload expired before it was completed")
+	}
+	if errors.Is(err, data.ErrQuotaExceeded) {
+		// The staged content stays until the upload expires, it completes once there is space again
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if err != nil {
+		log.Printf("CompleteMedia error: %v", err)
+		return status.Error(codes.Internal, "failed to store upload")
+	}
+	if err := s.staging.Remove(media.ID); err != nil {
+		log.Printf("Staging remove error: %v", err)
+	}
+	s.startScan(media.Hash)
+	return nil
+}
+
+// checkType sniffs the staged content and rejects it if it does not match the declared type.
+// Uploads declared as application/octet-stream get the sniffed type.
+func (s *MediaServer) checkType(media *models.Media) error {
+	file, err := s.staging.Open(media.ID)
+	if err != nil {
+		log.Printf("Staging open error: %v", err)
+		return status.Error(codes.Internal, "failed to store upload")
+	}
+	defer file.Close()
+
+	head := make([]byte, filetype.SniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		log.Printf("Staging read error: %v", err)
+		return status.Error(codes.Internal, "failed to store upload")
+	}
+
+	sniffed := filetype.Sniff(head[:n])
+	if !filetype.Matches(media.MimeType, sniffed) {
+		return s.rejectUpload(media, fmt.Errorf("content is %s, not %s", sniffed, media.MimeType))
+	}
+	if media.MimeType == filetype.TypeBinary {
+		media.MimeType = sniffed
+	}
+	if !s.config.Types.Allows(media.MimeType) {
+		return s.rejectUpload(media, fmt.Errorf("files of type %s are not allowed", media.MimeType))
+	}
+	return nil
+}
+
+// processImage validates the staged image and sets the details of the media from the image without metadata
+// Images are decoded in memory, so their size and dimensions are checked before the content is read.
+func (s *MediaServer) processImage(media *models.Media) (*imaging.Image, error) {
+	if media.UploadSize > s.config.MaxImageSize {
+		return nil, s.rejectUpload(media, fmt.Errorf("images must not exceed %d bytes", s.config.MaxImageSize))
+	}
+
+	file, err := s.staging.Open(media.ID)
+	if err != nil {
+		log.Printf("Staging open error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store upload")
+	}
+	err = imaging.Check(file)
+	file.Close()
+	if err != nil {
+		return nil, s.rejectUpload(media, err)
+	}
+
+	content, err := s.readStaged(media)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := imaging.Process(content)
+	if err != nil {
+		return nil, s.rejectUpload(media, err)
+	}
+
+	sum := sha256.Sum256(image.Data)
+	media.Hash = hex.EncodeToString(sum[:])
+	media.Size = int64(len(image.Data))
+	media.MimeType = image.MimeType
+	media.Width = image.Width
+	media.Height = image.Height
+	media.Thumbnails = make([]models.Thumbnail, len(image.Thumbnails))
+	for i, thumbnail := range image.Thumbnails {
+		media.Thumbnails[i] = models.Thumbnail{
+			Name:   thumbnail.Name,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+			Size:   int64(len(thumbnail.Data)),
+		}
+	}
+	return image, nil
+}
+
+// processAudio sets the duration and waveform of Ogg Opus and WAV voice recordings,
+// other audio is stored without them and cannot be sent as a voice message.
+// Recordings are analyzed in memory, audio larger than MaxVoiceSize is not analyzed.
+func (s *MediaServer) processAudio(media *models.Media) error {
+	if media.UploadSize > s.config.MaxVoiceSize {
+		return nil
+	}
+
+	content, err := s.readStaged(media)
+	if err != nil {
+		return err
+	}
+
+	audio, err := voice.Analyze(content)
+	if errors.Is(err, voice.ErrUnsupportedFormat) {
+		return nil
+	}
+	if err != nil {
+		return s.rejectUpload(media, err)
+	}
+
+	media.MimeType = audio.MimeType
+	media.Duration = audio.Duration
+	media.Waveform = audio.Waveform
+	return nil
+}
+
+func (s *MediaServer) readStaged(media *models.Media) ([]byte, error) {
+	file, err := s.staging.Open(media.ID)
+	if err != nil {
+		log.Printf("Staging open error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store upload")
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		log.Printf("Staging read error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to store upload")
+	}
+	return content, nil
+}
+
+// rejectUpload drops the staged content that failed validation, uploading it again cannot fix it
+func (s *MediaServer) rejectUpload(media *models.Media, err error) error {
+	if err := s.staging.Remove(media.ID); err != nil {
+		log.Printf("Staging remove error: %v", err)
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// storeImage stores the image and its thumbnails, objects stored before are kept
+func (s *MediaServer) storeImage(ctx context.Context, hash string, image *imaging.Image) error {
+	for _, thumbnail := range image.Thumbnails {
+		if err := s.putMissing(ctx, thumbnailKey(hash, thumbnail.Name), thumbnail.Data); err != nil {
+			return err
+		}
+	}
+	return s.putMissing(ctx, hash, image.Data)
+}
+
+func (s *MediaServer) putMissing(ctx context.Context, key string, data []byte) error {
+	exists, err := s.store.Exists(ctx, key)
+	if err != nil || exists {
+		return err
+	}
+	return s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)))
+}
+
+// thumbnailKey is the store key of an image thumbnail, next to the image itself
+func thumbnailKey(hash string, name string) string {
+	return hash + "-" + name
+}
+
+// Download streams the content of the media or one of its thumbnails starting at the requested offset
+func (s *MediaServer) Download(req *pb.DownloadRequest, stream pb.MediaService_DownloadServer) error {
+	userID, err := authenticatedUserID(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	media, err := s.findAccessibleMedia(req.MediaId, userID)
+	if err != nil {
+		return err
+	}
+	if media.CompletedAt == nil {
+		return status.Error(codes.FailedPrecondition, "upload is not complete")
+	}
+	// Owners can download their content while it is scanned
+	switch {
+	case media.ScanStatus == models.ScanInfected:
+		return status.Error(codes.FailedPrecondition, "media is quarantined")
+	case media.ScanStatus == models.ScanPending && media.OwnerID != userID:
+		return status.Error(codes.FailedPrecondition, "media is being scanned")
+	}
+
+	key, size := media.Hash, media.Size
+	if req.Thumbnail != "" {
+		found := false
+		for _, thumbnail := range media.Thumbnails {
+			if thumbnail.Name == req.Thumbnail {
+				key, size, found = thumbnailKey(media.Hash, thumbnail.Name), thumbnail.Size, true
+				break
+			}
+		}
+		if !found {
+			return status.Error(codes.NotFound, "thumbnail not found")
+		}
+	}
+
+	if req.Offset < 0 || req.Offset > size {
+		return status.Error(codes.OutOfRange, "offset is outside of the content")
+	}
+	if req.Offset == size {
+		return nil
+	}
+
+	reader, err := s.store.Get(stream.Context(), key, req.Offset)
+	if err != nil {
+		log.Printf("Blob get error: %v", err)
+		return status.Error(codes.Internal, "failed to load media")
+	}
+	defer reader.Close()
+
+	offset := req.Offset
+	buffer := make([]byte, downloadChunkSize)
+	for {
+		n, err := io.ReadFull(reader, buffer)
+		if n > 0 {
+			if err := stream.Send(&pb.DownloadChunk{Offset: offset, Data: buffer[:n]}); err != nil {
+				return err
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			log.Printf("Blob read error: %v", err)
+			return status.Error(codes.Internal, "failed to load media")
+		}
+	}
+}
+
+// GetMedia returns the details of an accessible media
+func (s *MediaServer) GetMedia(ctx context.Context, req *pb.GetMediaRequest) (*pb.GetMediaResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.findAccessibleMedia(req.MediaId, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetMediaResponse{Media: mediaToProto(media)}, nil
+}
+
+// GetStorageUsage returns how much of the storage quota the caller uses
+func (s *MediaServer) GetStorageUsage(ctx context.Context, req *pb.GetStorageUsageRequest) (*pb.GetStorageUsageResponse, error) {
+	userID, err := authenticatedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.media_repo.GetStorageUsage(userID)
+	if err != nil {
+		log.Printf("GetStorageUsage error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load storage usage")
+	}
+	return &pb.GetStorageUsageResponse{Used: used, Quota: s.config.UserQuota}, nil
+}
+
+// RunGarbageCollector collects unused media every GCInterval until the context is done
+func (s *MediaServer) RunGarbageCollector(ctx context.Context) {
+	ticker := time.NewTicker(s.config.GCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.CollectGarbage(ctx); err != nil {
+				log.Printf("Media garbage collection error: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// CollectGarbage deletes media no message or profile references once the grace period is over,
+// uploads not completed within it, and stored content no media uses anymore
+func (s *MediaServer) CollectGarbage(ctx context.Context) error {
+	deleted, err := s.media_repo.DeleteUnusedMedia(time.Now().Add(-s.config.GCGrace))
+	if err != nil {
+		return err
+	}
+	for _, media := range deleted {
+		if media.CompletedAt == nil {
+			if err := s.staging.Remove(media.ID); err != nil {
+				log.Printf("Staging remove error: %v", err)
+			}
+		}
+	}
+
+	for {
+		hashes, err := s.media_repo.GetUnusedBlobs(gcBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, hash := range hashes {
+			_, err := s.media_repo.DeleteBlob(hash, func() error {
+				for _, name := range imaging.ThumbnailNames {
+					if err := s.store.Delete(ctx, thumbnailKey(hash, name)); err != nil {
+						return err
+					}
+				}
+				return s.store.Delete(ctx, hash)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(hashes) < gcBatchSize {
+			return nil
+		}
+	}
+}
+
+// RunScanner scans content left unscanned every ScanInterval until the context is done,
+// such as content completed while the scanner was unreachable or before the server restarted
+func (s *MediaServer) RunScanner(ctx context.Context) {
+	ticker := time.NewTicker(s.config.ScanInterval)
+	defer ticker.Stop()
+
+	for {
+		hashes, err := s.media_repo.GetPendingScans(scanBatchSize)
+		if err != nil {
+			log.Printf("GetPendingScans error: %v", err)
+		}
+		for _, hash := range hashes {
+			s.startScan(hash)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// startScan scans the stored content in the background, unless it is being scanned already
+func (s *MediaServer) startScan(hash string) {
+	if _, busy := s.scanning.LoadOrStore(hash, true); busy {
+		return
+	}
+
+	go func() {
+		defer s.scanning.Delete(hash)
+		if err := s.scanContent(context.Background(), hash); err != nil {
+			log.Printf("Media scan error: %v", err)
+		}
+	}()
+}
+
+// scanContent scans the stored content and sets the result on every media waiting for it.
+// Content that could not be scanned stays pending and is scanned again by RunScanner.
+func (s *MediaServer) scanContent(ctx context.Context, hash string) error {
+	reader, err := s.store.Get(ctx, hash, 0)
+	if errors.Is(err, blob.ErrNotFound) {
+		// Collected in the meantime
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	result, err := s.scanner.Scan(ctx, reader)
+	if err != nil {
+		return err
+	}
+
+	scan_status, signature := models.ScanClean, ""
+	if !result.Clean {
+		scan_status, signature = models.ScanInfected, result.Signature
+		log.Printf("Media %s quarantined: %s", hash, signature)
+	}
+	messages, err := s.media_repo.SetScanResult(hash, scan_status, signature)
+	if err != nil {
+		return err
+	}
+	if len(messages) > 0 && s.scanned != nil {
+		s.scanned(messages, scan_status)
+	}
+	return nil
+}
+
+func (s *MediaServer) findMedia(rawMediaID string) (*models.Media, error) {
+	mediaID, err := utils.ParseID(rawMediaID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	media, err := s.media_repo.GetMedia(mediaID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.NotFound, "media not found")
+	}
+	if err != nil {
+		log.Printf("GetMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load media")
+	}
+	return media, nil
+}
+
+// findAccessibleMedia reports media the user cannot access as not found
+func (s *MediaServer) findAccessibleMedia(rawMediaID string, userID uint) (*models.Media, error) {
+	media, err := s.findMedia(rawMediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := s.media_repo.CanAccessMedia(media, userID)
+	if err != nil {
+		log.Printf("CanAccessMedia error: %v", err)
+		return nil, status.Error(codes.Internal, "failed to load media")
+	}
+	if !allowed {
+		return nil, status.Error(codes.NotFound, "media not found")
+	}
+	return media, nil
+}
+
+func mediaToProto(media *models.Media) *pb.Media {
+	result := &pb.Media{
+		Id:         utils.FormatID(media.ID),
+		OwnerId:    utils.FormatID(media.OwnerID),
+		Sha256:     media.Hash,
+		Size:       media.Size,
+		MimeType:   media.MimeType,
+		CreatedAt:  media.CreatedAt.UnixMilli(),
+		Complete:   media.CompletedAt != nil,
+		Width:      int32(media.Width),
+		Height:     int32(media.Height),
+		DurationMs: media.Duration.Milliseconds(),
+		Waveform:   media.Waveform,
+
+		ScanStatus:    scanStatusToProto(media.ScanStatus),
+		ScanSignature: media.ScanSignature,
+	}
+	for _, thumbnail := range media.Thumbnails {
+		result.Thumbnails = append(result.Thumbnails, &pb.Thumbnail{
+			Name:   thumbnail.Name,
+			Width:  int32(thumbnail.Width),
+			Height: int32(thumbnail.Height),
+			Size:   thumbnail.Size,
+		})
+	}
+	return result
+}
+
+func scanStatusToProto(scan_status string) pb.ScanStatus {
+	switch scan_status {
+	case models.ScanClean:
+		return pb.ScanStatus_SCAN_CLEAN
+	case models.ScanInfected:
+		return pb.ScanStatus_SCAN_INFECTED
+	}
+	return pb.ScanStatus_SCAN_PENDING
+}
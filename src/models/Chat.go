@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// Chat types, mirroring ChatType
+const (
+	ChatTypeGroup   = "group"
+	ChatTypeDirect  = "direct"
+	ChatTypeChannel = "channel"
+)
+
+type Chat struct {
+	ID           uint              `json:"id"`
+	Type         string            `gorm:"size:16;not null;default:group" json:"type"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	CreatorID    uint              `json:"creator_id"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Participants []ChatParticipant `gorm:"foreignKey:ChatID;constraint:OnDelete:CASCADE" json:"participants,omitempty"`
+
+	// Denormalized for the chat list, updated with every new message
+	LastMessageID  *uint     `json:"last_message_id"`
+	LastActivityAt time.Time `gorm:"index" json:"last_activity_at"`
+
+	// DirectKey identifies the pair of users of a direct chat, so every pair has at most one
+	DirectKey *string `gorm:"uniqueIndex" json:"-"`
+}
+
+// Chat roles: the owner has every permission, admins manage members and messages
+const (
+	ChatRoleOwner  = "owner"
+	ChatRoleAdmin  = "admin"
+	ChatRoleMember = "member"
+)
+
+type ChatParticipant struct {
+	ChatID   uint      `gorm:"primaryKey" json:"chat_id"`
+	UserID   uint      `gorm:"primaryKey;index" json:"user_id"`
+	Role     string    `gorm:"size:16;not null;default:member" json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+
+	// Receipt watermarks: every message up to these IDs is delivered/read by the user
+	LastDeliveredMessageID uint `gorm:"not null;default:0" json:"last_delivered_message_id"`
+	LastReadMessageID      uint `gorm:"not null;default:0" json:"last_read_message_id"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+// ChatEventPayload keeps pub/sub payloads that are too large for a Postgres NOTIFY
+type ChatEventPayload struct {
+	ID        uint      `json:"id"`
+	Data      []byte    `json:"data"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// Join request statuses
+const (
+	JoinRequestPending  = "pending"
+	JoinRequestApproved = "approved"
+	JoinRequestRejected = "rejected"
+)
+
+// ChatInvite is a shareable code that lets users join a chat
+type ChatInvite struct {
+	ID        uint      `json:"id"`
+	ChatID    uint      `gorm:"index" json:"chat_id"`
+	Code      string    `gorm:"size:32;uniqueIndex" json:"code"`
+	CreatorID uint      `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// ExpiresAt is nil for invites that never expire
+	ExpiresAt *time.Time `json:"expires_at"`
+	// MaxUses is the number of users who can join with the invite, 0 means unlimited
+	MaxUses int `gorm:"not null;default:0" json:"max_uses"`
+	Uses    int `gorm:"not null;default:0" json:"uses"`
+	// RequiresApproval puts users into the join request queue instead of adding them
+	RequiresApproval bool       `json:"requires_approval"`
+	RevokedAt        *time.Time `json:"revoked_at"`
+}
+
+// ChatJoinRequest is a request to join a chat with an invite that requires approval.
+// A user has at most one request per chat, asking again reopens it.
+type ChatJoinRequest struct {
+	ID        uint       `json:"id"`
+	ChatID    uint       `gorm:"uniqueIndex:idx_join_requests_chat_user,priority:1" json:"chat_id"`
+	UserID    uint       `gorm:"uniqueIndex:idx_join_requests_chat_user,priority:2" json:"user_id"`
+	InviteID  uint       `json:"invite_id"`
+	Status    string     `gorm:"size:16;index" json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	DecidedBy *uint      `json:"decided_by"`
+	DecidedAt *time.Time `json:"decided_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// LinkPreview caches the Open Graph details of a link sent in messages, keyed by the link as written.
+// Links without a preview are cached with empty fields, so they are not fetched again before the entry expires.
+type LinkPreview struct {
+	URL         string    `gorm:"primaryKey;size:2048" json:"url"`
+	Title       string    `gorm:"size:300" json:"title"`
+	Description string    `gorm:"size:1000" json:"description"`
+	ImageURL    string    `gorm:"size:2048" json:"image_url"`
+	FetchedAt   time.Time `gorm:"not null" json:"fetched_at"`
+}
+
+// Empty checks if the link has nothing to show
+func (p *LinkPreview) Empty() bool {
+	return p.Title == "" && p.Description == "" && p.ImageURL == ""
+}
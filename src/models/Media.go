@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Scan statuses of media content, content is visible to other users once it is clean
+const (
+	ScanPending  = "pending"
+	ScanClean    = "clean"
+	ScanInfected = "infected"
+)
+
+// Blob is stored content shared by every media with the same hash.
+// Its row is locked while media starts or stops using the content.
+type Blob struct {
+	Hash      string    `gorm:"primaryKey;size:64" json:"hash"`
+	Size      int64     `gorm:"not null" json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Media is an uploaded file. Its content is stored once per hash in the blob store,
+// so several media rows may share the same content.
+type Media struct {
+	ID      uint `gorm:"primaryKey" json:"id"`
+	OwnerID uint `gorm:"index;not null" json:"owner_id"`
+	// UploadHash is the hex SHA-256 of the content declared by the uploader, verified when the upload completes
+	UploadHash string `gorm:"size:64;index" json:"upload_hash"`
+	UploadSize int64  `json:"upload_size"`
+	// Hash and Size describe the stored content. They match the upload until it completes,
+	// images are stored without their metadata.
+	Hash      string    `gorm:"size:64;index;not null" json:"hash"`
+	Size      int64     `gorm:"not null" json:"size"`
+	MimeType  string    `gorm:"size:128;not null" json:"mime_type"`
+	CreatedAt time.Time `json:"created_at"`
+	// CompletedAt is nil while the upload is in progress
+	CompletedAt *time.Time `json:"completed_at"`
+
+	// Width and Height are set for images
+	Width  int `gorm:"not null;default:0" json:"width"`
+	Height int `gorm:"not null;default:0" json:"height"`
+	// Thumbnails of images are stored next to the content, keyed by its hash and the thumbnail name
+	Thumbnails []Thumbnail `gorm:"type:jsonb;serializer:json" json:"thumbnails,omitempty"`
+
+	// Duration and Waveform are set for voice recordings, Waveform holds loudness values from 0 to 255
+	Duration time.Duration `gorm:"not null;default:0" json:"duration"`
+	Waveform []byte        `json:"waveform,omitempty"`
+
+	// ScanStatus is pending until the completed content was scanned for malware, infected media is quarantined
+	ScanStatus    string `gorm:"size:16;not null;default:pending;index" json:"scan_status"`
+	ScanSignature string `gorm:"size:255" json:"scan_signature,omitempty"`
+}
+
+// Thumbnail is a downscaled JPEG copy of an image
+type Thumbnail struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Size   int64  `json:"size"`
+}
+
+// StorageUsage is the total size of the completed media a user owns, checked against the storage quota
+type StorageUsage struct {
+	UserID    uint      `gorm:"primaryKey" json:"user_id"`
+	Bytes     int64     `gorm:"not null;default:0" json:"bytes"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
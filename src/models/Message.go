@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+)
+
+// Message content kinds, mirroring the ChatMessage.content oneof
+const (
+	MessageKindText  = "text"
+	MessageKindAudio = "audio"
+	MessageKindImage = "image"
+	MessageKindFile  = "file"
+	// MessageKindSystem messages are posted by the server, the sender is the user who caused them
+	MessageKindSystem = "system"
+)
+
+// System message actions, mirroring SystemEvent.Type
+const (
+	SystemActionMembersAdded         = "members_added"
+	SystemActionMemberRemoved        = "member_removed"
+	SystemActionMemberLeft           = "member_left"
+	SystemActionOwnershipTransferred = "ownership_transferred"
+	SystemActionRoleChanged          = "role_changed"
+	SystemActionMemberJoined         = "member_joined"
+)
+
+// Message statuses, mirroring ChatMessage.status
+const (
+	MessageStatusSent     int32 = 0
+	MessageStatusReceived int32 = 1
+	MessageStatusRead     int32 = 2
+)
+
+type Message struct {
+	ID        uint       `gorm:"primaryKey;index:idx_messages_chat_id,priority:2" json:"id"`
+	ChatID    uint       `gorm:"index:idx_messages_chat_created,priority:1;index:idx_messages_chat_id,priority:1" json:"chat_id"`
+	SenderID  uint       `gorm:"index" json:"sender_id"`
+	Kind      string     `gorm:"size:16" json:"kind"`
+	Text      string     `json:"text"`
+	AudioData []byte     `json:"audio_data,omitempty"`
+	ImageData []byte     `json:"image_data,omitempty"`
+	Status    int32      `json:"status"`
+	CreatedAt time.Time  `gorm:"index:idx_messages_chat_created,priority:2;index:idx_messages_thread,priority:2" json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+	// DeletedAt is set when the message is deleted for everyone, the row stays as a tombstone
+	DeletedAt *time.Time `json:"deleted_at"`
+	// System describes the change announced by a system message
+	System *SystemPayload `gorm:"type:jsonb;serializer:json" json:"system,omitempty"`
+
+	// ReplyToID is the quoted message, ThreadRootID is the first message of the reply chain
+	ReplyToID    *uint `json:"reply_to_id"`
+	ThreadRootID *uint `gorm:"index:idx_messages_thread,priority:1" json:"thread_root_id"`
+	// ReplyCount is the number of messages in the thread, only set on thread roots
+	ReplyCount int `gorm:"not null;default:0" json:"reply_count"`
+	// ReplyTo is the quoted message without audio or image data, loaded on demand
+	ReplyTo *Message `gorm:"-" json:"reply_to,omitempty"`
+
+	// MediaID is the uploaded content of audio and image messages,
+	// AudioData and ImageData only hold the inline content of old messages
+	MediaID *uint `gorm:"index" json:"media_id"`
+	// Media is loaded on demand
+	Media *Media `gorm:"-" json:"media,omitempty"`
+	// Filename is the name of the attachment of file messages
+	Filename string `gorm:"size:255" json:"filename,omitempty"`
+
+	// ViewCount is the number of subscribers who read a channel post
+	ViewCount int `gorm:"not null;default:0" json:"view_count"`
+
+	Mentions []MessageMention `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE" json:"mentions,omitempty"`
+}
+
+// SystemPayload describes a membership change announced by a system message
+type SystemPayload struct {
+	Action  string `json:"action"`
+	UserIDs []uint `json:"user_ids,omitempty"`
+	// Role is the new role of the users for role changes
+	Role string `json:"role,omitempty"`
+}
+
+// MessageMention marks a user mentioned with @username in a message
+type MessageMention struct {
+	MessageID uint `gorm:"primaryKey" json:"message_id"`
+	UserID    uint `gorm:"primaryKey;index" json:"user_id"`
+}
+
+// MessageEdit keeps a previous version of an edited message
+type MessageEdit struct {
+	ID        uint      `json:"id"`
+	MessageID uint      `gorm:"index" json:"message_id"`
+	EditorID  uint      `json:"editor_id"`
+	Text      string    `json:"text"`
+	EditedAt  time.Time `json:"edited_at"`
+}
+
+// HiddenMessage is a message the user deleted only for themselves
+type HiddenMessage struct {
+	MessageID uint      `gorm:"primaryKey" json:"message_id"`
+	UserID    uint      `gorm:"primaryKey;index" json:"user_id"`
+	HiddenAt  time.Time `json:"hidden_at"`
+}
+
+// MessageReaction is an emoji reaction of a user to a message
+type MessageReaction struct {
+	MessageID uint      `gorm:"primaryKey" json:"message_id"`
+	UserID    uint      `gorm:"primaryKey;index" json:"user_id"`
+	Emoji     string    `gorm:"primaryKey;size:64" json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// MessageReceipt tracks when a recipient received and read a message
+type MessageReceipt struct {
+	MessageID   uint       `gorm:"primaryKey" json:"message_id"`
+	UserID      uint       `gorm:"primaryKey;index" json:"user_id"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+	ReadAt      *time.Time `json:"read_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PinnedMessage is a message pinned to the top of a chat
+type PinnedMessage struct {
+	ChatID    uint      `gorm:"primaryKey" json:"chat_id"`
+	MessageID uint      `gorm:"primaryKey;index" json:"message_id"`
+	PinnedBy  uint      `gorm:"not null" json:"pinned_by"`
+	PinnedAt  time.Time `gorm:"not null" json:"pinned_at"`
+
+	// Loaded separately, filtered for the viewer
+	Message *Message `gorm:"-" json:"message,omitempty"`
+}
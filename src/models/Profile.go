@@ -11,4 +11,7 @@ type Profile struct {
 	Avatar_url   string    `json:"avatar_url"`
 	Status       string    `json:"status"`
 	Last_seen    time.Time `json:"last_seen"`
+
+	// Avatar_media_id is an uploaded avatar image, it keeps the media from garbage collection
+	Avatar_media_id *uint `gorm:"index" json:"avatar_media_id"`
 }
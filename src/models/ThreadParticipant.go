@@ -0,0 +1,10 @@
+package models
+
+// ThreadParticipant follows a reply thread: the author of the root message and everyone who replied
+type ThreadParticipant struct {
+	RootMessageID uint `gorm:"primaryKey" json:"root_message_id"`
+	UserID        uint `gorm:"primaryKey;index" json:"user_id"`
+
+	// Every reply up to this ID is read by the user
+	LastReadMessageID uint `gorm:"not null;default:0" json:"last_read_message_id"`
+}
@@ -8,17 +8,19 @@ import (
 	"alexchatapp/src/utils"
 	"context"
 	"errors"
-	"log"
+	"strings"
 )
 
 type ProfileServer struct {
 	pb.UnimplementedProfileServiceServer
 	profile_repo *data.ProfilesRepository
+	media_repo   *data.MediaRepository
 }
 
-func NewProfilesServer(profile_repo *data.ProfilesRepository) *ProfileServer {
+func NewProfilesServer(profile_repo *data.ProfilesRepository, media_repo *data.MediaRepository) *ProfileServer {
 	return &ProfileServer{
 		profile_repo: profile_repo,
+		media_repo:   media_repo,
 	}
 }
 
@@ -26,7 +28,6 @@ func (p *ProfileServer) CreateProfile(ctx context.Context, req *pb.CreateProfile
 	var new_profile models.Profile
 
 	userIDValue, ok := jwt.GetUserIdFromContext(ctx)
-	log.Println(userIDValue)
 	if !ok {
 		return nil, errors.New("user not authenticated")
 	}
@@ -53,6 +54,16 @@ func (p *ProfileServer) CreateProfile(ctx context.Context, req *pb.CreateProfile
 		Status:       *req.Status,
 	}
 
+	if req.AvatarMediaId != nil {
+		avatar, err := p.avatarMedia(userID, *req.AvatarMediaId)
+		if err != nil {
+			return &pb.CreateProfileResponse{
+				StatusCode: 400,
+			}, err
+		}
+		new_profile.Avatar_media_id = avatar
+	}
+
 	err := p.profile_repo.CreateProfileByModel(new_profile)
 	if err != nil {
 		return &pb.CreateProfileResponse{
@@ -69,7 +80,6 @@ func (p *ProfileServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfile
 	var updated_profile models.Profile
 
 	userIDValue, ok := jwt.GetUserIdFromContext(ctx)
-	log.Println(userIDValue)
 	if !ok {
 		return nil, errors.New("user not authenticated")
 	}
@@ -95,6 +105,18 @@ func (p *ProfileServer) UpdateProfile(ctx context.Context, req *pb.UpdateProfile
 		Bio:          *req.Bio,
 		Avatar_url:   *req.AvatarUrl,
 		Status:       *req.Status,
+
+		Avatar_media_id: profile.Avatar_media_id,
+	}
+
+	if req.AvatarMediaId != nil {
+		avatar, err := p.avatarMedia(userID, *req.AvatarMediaId)
+		if err != nil {
+			return &pb.UpdateProfileResponse{
+				StatusCode: 400,
+			}, err
+		}
+		updated_profile.Avatar_media_id = avatar
 	}
 
 	if err := p.profile_repo.UpdateProfile(&updated_profile); err != nil {
@@ -112,7 +134,6 @@ func (p *ProfileServer) GetProfile(ctx context.Context, req *pb.GetProfileReques
 	var profile *pb.Profile
 
 	userIDValue, ok := jwt.GetUserIdFromContext(ctx)
-	log.Println(userIDValue)
 	if !ok {
 		return nil, errors.New("user not authenticated")
 	}
@@ -137,6 +158,10 @@ func (p *ProfileServer) GetProfile(ctx context.Context, req *pb.GetProfileReques
 		AvatarUrl:   &profileModel.Avatar_url,
 		Status:      &profileModel.Status,
 	}
+	if profileModel.Avatar_media_id != nil {
+		avatar := utils.FormatID(*profileModel.Avatar_media_id)
+		profile.AvatarMediaId = &avatar
+	}
 
 	return &pb.GetProfileResponse{
 		Profile: profile,
@@ -145,7 +170,6 @@ func (p *ProfileServer) GetProfile(ctx context.Context, req *pb.GetProfileReques
 
 func (p *ProfileServer) UpdateOnlineStatus(ctx context.Context, req *pb.UpdateOnlineStatusRequest) (*pb.UpdateOnlineStatusResponse, error) {
 	userIDValue, ok := jwt.GetUserIdFromContext(ctx)
-	log.Println(userIDValue)
 	if !ok {
 		return nil, errors.New("user not authenticated")
 	}
@@ -169,3 +193,30 @@ func (p *ProfileServer) UpdateOnlineStatus(ctx context.Context, req *pb.UpdateOn
 		StatusCode: 200,
 	}, nil
 }
+
+// avatarMedia checks that the avatar is a completed image uploaded by the user, an empty id removes the avatar
+func (p *ProfileServer) avatarMedia(userID uint, rawMediaID string) (*uint, error) {
+	if rawMediaID == "" {
+		return nil, nil
+	}
+
+	mediaID, err := utils.ParseID(rawMediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := p.media_repo.GetMedia(mediaID)
+	if err != nil || media.OwnerID != userID {
+		return nil, errors.New("avatar media not found")
+	}
+	if media.CompletedAt == nil {
+		return nil, errors.New("avatar upload is not complete")
+	}
+	if !strings.HasPrefix(media.MimeType, "image/") {
+		return nil, errors.New("avatar must be an image")
+	}
+	if media.ScanStatus == models.ScanInfected {
+		return nil, errors.New("avatar media is quarantined")
+	}
+	return &media.ID, nil
+}
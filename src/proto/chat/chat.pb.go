@@ -21,6 +21,155 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Attachments are scanned for malware before the other participants can download them
+type AttachmentState int32
+
+const (
+	AttachmentState_READY    AttachmentState = 0
+	AttachmentState_SCANNING AttachmentState = 1
+	// Malware was found, nobody can download the attachment
+	AttachmentState_QUARANTINED AttachmentState = 2
+)
+
+// Enum value maps for AttachmentState.
+var (
+	AttachmentState_name = map[int32]string{
+		0: "READY",
+		1: "SCANNING",
+		2: "QUARANTINED",
+	}
+	AttachmentState_value = map[string]int32{
+		"READY":       0,
+		"SCANNING":    1,
+		"QUARANTINED": 2,
+	}
+)
+
+func (x AttachmentState) Enum() *AttachmentState {
+	p := new(AttachmentState)
+	*p = x
+	return p
+}
+
+func (x AttachmentState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AttachmentState) Descriptor() protoreflect.EnumDescriptor {
+	return file_src_proto_chat_proto_enumTypes[0].Descriptor()
+}
+
+func (AttachmentState) Type() protoreflect.EnumType {
+	return &file_src_proto_chat_proto_enumTypes[0]
+}
+
+func (x AttachmentState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AttachmentState.Descriptor instead.
+func (AttachmentState) EnumDescriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{0}
+}
+
+type ChatRole int32
+
+const (
+	ChatRole_MEMBER ChatRole = 0
+	ChatRole_ADMIN  ChatRole = 1
+	ChatRole_OWNER  ChatRole = 2
+)
+
+// Enum value maps for ChatRole.
+var (
+	ChatRole_name = map[int32]string{
+		0: "MEMBER",
+		1: "ADMIN",
+		2: "OWNER",
+	}
+	ChatRole_value = map[string]int32{
+		"MEMBER": 0,
+		"ADMIN":  1,
+		"OWNER":  2,
+	}
+)
+
+func (x ChatRole) Enum() *ChatRole {
+	p := new(ChatRole)
+	*p = x
+	return p
+}
+
+func (x ChatRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_src_proto_chat_proto_enumTypes[1].Descriptor()
+}
+
+func (ChatRole) Type() protoreflect.EnumType {
+	return &file_src_proto_chat_proto_enumTypes[1]
+}
+
+func (x ChatRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatRole.Descriptor instead.
+func (ChatRole) EnumDescriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{1}
+}
+
+type ChatType int32
+
+const (
+	ChatType_GROUP   ChatType = 0
+	ChatType_DIRECT  ChatType = 1
+	ChatType_CHANNEL ChatType = 2
+)
+
+// Enum value maps for ChatType.
+var (
+	ChatType_name = map[int32]string{
+		0: "GROUP",
+		1: "DIRECT",
+		2: "CHANNEL",
+	}
+	ChatType_value = map[string]int32{
+		"GROUP":   0,
+		"DIRECT":  1,
+		"CHANNEL": 2,
+	}
+)
+
+func (x ChatType) Enum() *ChatType {
+	p := new(ChatType)
+	*p = x
+	return p
+}
+
+func (x ChatType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChatType) Descriptor() protoreflect.EnumDescriptor {
+	return file_src_proto_chat_proto_enumTypes[2].Descriptor()
+}
+
+func (ChatType) Type() protoreflect.EnumType {
+	return &file_src_proto_chat_proto_enumTypes[2]
+}
+
+func (x ChatType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChatType.Descriptor instead.
+func (ChatType) EnumDescriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{2}
+}
+
 type ChatMessageStatus int32
 
 const (
@@ -54,11 +203,11 @@ func (x ChatMessageStatus) String() string {
 }
 
 func (ChatMessageStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_src_proto_chat_proto_enumTypes[0].Descriptor()
+	return file_src_proto_chat_proto_enumTypes[3].Descriptor()
 }
 
 func (ChatMessageStatus) Type() protoreflect.EnumType {
-	return &file_src_proto_chat_proto_enumTypes[0]
+	return &file_src_proto_chat_proto_enumTypes[3]
 }
 
 func (x ChatMessageStatus) Number() protoreflect.EnumNumber {
@@ -70,6 +219,114 @@ func (ChatMessageStatus) EnumDescriptor() ([]byte, []int) {
 	return file_src_proto_chat_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type SystemEvent_Type int32
+
+const (
+	SystemEvent_UNKNOWN               SystemEvent_Type = 0
+	SystemEvent_MEMBERS_ADDED         SystemEvent_Type = 1
+	SystemEvent_MEMBER_REMOVED        SystemEvent_Type = 2
+	SystemEvent_MEMBER_LEFT           SystemEvent_Type = 3
+	SystemEvent_OWNERSHIP_TRANSFERRED SystemEvent_Type = 4
+	SystemEvent_ROLE_CHANGED          SystemEvent_Type = 5
+	// The sender joined with an invite
+	SystemEvent_MEMBER_JOINED SystemEvent_Type = 6
+)
+
+// Enum value maps for SystemEvent_Type.
+var (
+	SystemEvent_Type_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "MEMBERS_ADDED",
+		2: "MEMBER_REMOVED",
+		3: "MEMBER_LEFT",
+		4: "OWNERSHIP_TRANSFERRED",
+		5: "ROLE_CHANGED",
+		6: "MEMBER_JOINED",
+	}
+	SystemEvent_Type_value = map[string]int32{
+		"UNKNOWN":               0,
+		"MEMBERS_ADDED":         1,
+		"MEMBER_REMOVED":        2,
+		"MEMBER_LEFT":           3,
+		"OWNERSHIP_TRANSFERRED": 4,
+		"ROLE_CHANGED":          5,
+		"MEMBER_JOINED":         6,
+	}
+)
+
+func (x SystemEvent_Type) Enum() *SystemEvent_Type {
+	p := new(SystemEvent_Type)
+	*p = x
+	return p
+}
+
+func (x SystemEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SystemEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_src_proto_chat_proto_enumTypes[4].Descriptor()
+}
+
+func (SystemEvent_Type) Type() protoreflect.EnumType {
+	return &file_src_proto_chat_proto_enumTypes[4]
+}
+
+func (x SystemEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SystemEvent_Type.Descriptor instead.
+func (SystemEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type GetMessagesRequest_Direction int32
+
+const (
+	GetMessagesRequest_BACKWARD GetMessagesRequest_Direction = 0
+	GetMessagesRequest_FORWARD  GetMessagesRequest_Direction = 1
+)
+
+// Enum value maps for GetMessagesRequest_Direction.
+var (
+	GetMessagesRequest_Direction_name = map[int32]string{
+		0: "BACKWARD",
+		1: "FORWARD",
+	}
+	GetMessagesRequest_Direction_value = map[string]int32{
+		"BACKWARD": 0,
+		"FORWARD":  1,
+	}
+)
+
+func (x GetMessagesRequest_Direction) Enum() *GetMessagesRequest_Direction {
+	p := new(GetMessagesRequest_Direction)
+	*p = x
+	return p
+}
+
+func (x GetMessagesRequest_Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetMessagesRequest_Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_src_proto_chat_proto_enumTypes[5].Descriptor()
+}
+
+func (GetMessagesRequest_Direction) Type() protoreflect.EnumType {
+	return &file_src_proto_chat_proto_enumTypes[5]
+}
+
+func (x GetMessagesRequest_Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetMessagesRequest_Direction.Descriptor instead.
+func (GetMessagesRequest_Direction) EnumDescriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{23, 0}
+}
+
 type ChatMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -82,7 +339,33 @@ type ChatMessage struct {
 	//	*ChatMessage_Text
 	//	*ChatMessage_AudioData
 	//	*ChatMessage_ImageData
-	Content       isChatMessage_Content `protobuf_oneof:"content"`
+	//	*ChatMessage_System
+	//	*ChatMessage_Image
+	//	*ChatMessage_Audio
+	//	*ChatMessage_File
+	Content isChatMessage_Content `protobuf_oneof:"content"`
+	// Set when the text was edited
+	EditedAt int64 `protobuf:"varint,9,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	// Deleted for everyone, the message has no content
+	Deleted bool `protobuf:"varint,10,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	// Aggregated reactions, ordered by first use
+	Reactions []*ReactionSummary `protobuf:"bytes,11,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	// Set by the client to reply to a message of the same chat
+	ReplyToId string `protobuf:"bytes,12,opt,name=reply_to_id,json=replyToId,proto3" json:"reply_to_id,omitempty"`
+	// Set by the server: the quoted message
+	ReplyTo *MessagePreview `protobuf:"bytes,13,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	// Set by the server on replies: the first message of the reply chain
+	ThreadRootId string `protobuf:"bytes,14,opt,name=thread_root_id,json=threadRootId,proto3" json:"thread_root_id,omitempty"`
+	// Set on thread roots
+	ReplyCount int32 `protobuf:"varint,15,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
+	// Replies the caller has not read yet, only in threads the caller follows
+	ThreadUnreadCount int32 `protobuf:"varint,16,opt,name=thread_unread_count,json=threadUnreadCount,proto3" json:"thread_unread_count,omitempty"`
+	// Set on channel posts: subscribers who read the post
+	ViewCount int32 `protobuf:"varint,18,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	// Set by the server on image, audio and file messages
+	AttachmentState AttachmentState `protobuf:"varint,22,opt,name=attachment_state,json=attachmentState,proto3,enum=alexchatapp.AttachmentState" json:"attachment_state,omitempty"`
+	// Set by the server on text messages once the links in the text were fetched, in the order of the links
+	LinkPreviews  []*LinkPreview `protobuf:"bytes,23,rep,name=link_previews,json=linkPreviews,proto3" json:"link_previews,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -145,91 +428,5155 @@ func (x *ChatMessage) GetTimestamp() int64 {
 	return 0
 }
 
-func (x *ChatMessage) GetMessageStatus() ChatMessageStatus {
+func (x *ChatMessage) GetMessageStatus() ChatMessageStatus {
+	if x != nil {
+		return x.MessageStatus
+	}
+	return ChatMessage_SENT
+}
+
+func (x *ChatMessage) GetContent() isChatMessage_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetText() string {
+	if x != nil {
+		if x, ok := x.Content.(*ChatMessage_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in src/proto/chat.proto.
+func (x *ChatMessage) GetAudioData() []byte {
+	if x != nil {
+		if x, ok := x.Content.(*ChatMessage_AudioData); ok {
+			return x.AudioData
+		}
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in src/proto/chat.proto.
+func (x *ChatMessage) GetImageData() []byte {
+	if x != nil {
+		if x, ok := x.Content.(*ChatMessage_ImageData); ok {
+			return x.ImageData
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetSystem() *SystemEvent {
+	if x != nil {
+		if x, ok := x.Content.(*ChatMessage_System); ok {
+			return x.System
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetImage() *MediaAttachment {
+	if x != nil {
+		if x, ok := x.Content.(*ChatMessage_Image); ok {
+			return x.Image
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetAudio() *MediaAttachment {
+	if x != nil {
+		if x, ok := x.Content.(*ChatMessage_Audio); ok {
+			return x.Audio
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetFile() *FileAttachment {
+	if x != nil {
+		if x, ok := x.Content.(*ChatMessage_File); ok {
+			return x.File
+		}
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetEditedAt() int64 {
+	if x != nil {
+		return x.EditedAt
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *ChatMessage) GetReactions() []*ReactionSummary {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetReplyToId() string {
+	if x != nil {
+		return x.ReplyToId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetReplyTo() *MessagePreview {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return nil
+}
+
+func (x *ChatMessage) GetThreadRootId() string {
+	if x != nil {
+		return x.ThreadRootId
+	}
+	return ""
+}
+
+func (x *ChatMessage) GetReplyCount() int32 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetThreadUnreadCount() int32 {
+	if x != nil {
+		return x.ThreadUnreadCount
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetViewCount() int32 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *ChatMessage) GetAttachmentState() AttachmentState {
+	if x != nil {
+		return x.AttachmentState
+	}
+	return AttachmentState_READY
+}
+
+func (x *ChatMessage) GetLinkPreviews() []*LinkPreview {
+	if x != nil {
+		return x.LinkPreviews
+	}
+	return nil
+}
+
+type isChatMessage_Content interface {
+	isChatMessage_Content()
+}
+
+type ChatMessage_Text struct {
+	Text string `protobuf:"bytes,6,opt,name=text,proto3,oneof"`
+}
+
+type ChatMessage_AudioData struct {
+	// Deprecated: upload with MediaService and send audio.
+	// Stored as media and sent as audio, history still returns it for old messages.
+	//
+	// Deprecated: Marked as deprecated in src/proto/chat.proto.
+	AudioData []byte `protobuf:"bytes,7,opt,name=audio_data,json=audioData,proto3,oneof"`
+}
+
+type ChatMessage_ImageData struct {
+	// Deprecated: upload with MediaService and send image.
+	// Stored as media and sent as image, history still returns it for old messages.
+	//
+	// Deprecated: Marked as deprecated in src/proto/chat.proto.
+	ImageData []byte `protobuf:"bytes,8,opt,name=image_data,json=imageData,proto3,oneof"`
+}
+
+type ChatMessage_System struct {
+	// Posted by the server, sender_id is the user who caused the change
+	System *SystemEvent `protobuf:"bytes,17,opt,name=system,proto3,oneof"`
+}
+
+type ChatMessage_Image struct {
+	Image *MediaAttachment `protobuf:"bytes,19,opt,name=image,proto3,oneof"`
+}
+
+type ChatMessage_Audio struct {
+	Audio *MediaAttachment `protobuf:"bytes,20,opt,name=audio,proto3,oneof"`
+}
+
+type ChatMessage_File struct {
+	File *FileAttachment `protobuf:"bytes,21,opt,name=file,proto3,oneof"`
+}
+
+func (*ChatMessage_Text) isChatMessage_Content() {}
+
+func (*ChatMessage_AudioData) isChatMessage_Content() {}
+
+func (*ChatMessage_ImageData) isChatMessage_Content() {}
+
+func (*ChatMessage_System) isChatMessage_Content() {}
+
+func (*ChatMessage_Image) isChatMessage_Content() {}
+
+func (*ChatMessage_Audio) isChatMessage_Content() {}
+
+func (*ChatMessage_File) isChatMessage_Content() {}
+
+// Open Graph details of a web page linked in a text message
+type LinkPreview struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Url         string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Absolute link, clients load the image themselves
+	ImageUrl      string `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkPreview) Reset() {
+	*x = LinkPreview{}
+	mi := &file_src_proto_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkPreview) ProtoMessage() {}
+
+func (x *LinkPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkPreview.ProtoReflect.Descriptor instead.
+func (*LinkPreview) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LinkPreview) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkPreview) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+type MediaAttachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Media uploaded by the sender or sent to one of the sender's chats
+	MediaId string `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`
+	// Set by the server
+	MimeType string `protobuf:"bytes,2,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size     int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// Set by the server for images
+	Width      int32             `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height     int32             `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Thumbnails []*ImageThumbnail `protobuf:"bytes,6,rep,name=thumbnails,proto3" json:"thumbnails,omitempty"`
+	// Set by the server for voice messages
+	DurationMs int64 `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Loudness from 0 to 255 over the recording, up to 100 values
+	Waveform      []byte `protobuf:"bytes,8,opt,name=waveform,proto3" json:"waveform,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaAttachment) Reset() {
+	*x = MediaAttachment{}
+	mi := &file_src_proto_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaAttachment) ProtoMessage() {}
+
+func (x *MediaAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaAttachment.ProtoReflect.Descriptor instead.
+func (*MediaAttachment) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MediaAttachment) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+func (x *MediaAttachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *MediaAttachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *MediaAttachment) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MediaAttachment) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MediaAttachment) GetThumbnails() []*ImageThumbnail {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+func (x *MediaAttachment) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *MediaAttachment) GetWaveform() []byte {
+	if x != nil {
+		return x.Waveform
+	}
+	return nil
+}
+
+type FileAttachment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Media uploaded by the sender or sent to one of the sender's chats
+	MediaId string `protobuf:"bytes,1,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`
+	// Shown to the recipients, without directories
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// Set by the server, the type is sniffed from the content when the upload completes
+	MimeType      string `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	Size          int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileAttachment) Reset() {
+	*x = FileAttachment{}
+	mi := &file_src_proto_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileAttachment) ProtoMessage() {}
+
+func (x *FileAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileAttachment.ProtoReflect.Descriptor instead.
+func (*FileAttachment) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FileAttachment) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+func (x *FileAttachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *FileAttachment) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *FileAttachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Downloaded with MediaService.Download using the media id and the thumbnail name
+type ImageThumbnail struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "small" or "medium"
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Width         int32  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32  `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImageThumbnail) Reset() {
+	*x = ImageThumbnail{}
+	mi := &file_src_proto_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImageThumbnail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageThumbnail) ProtoMessage() {}
+
+func (x *ImageThumbnail) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageThumbnail.ProtoReflect.Descriptor instead.
+func (*ImageThumbnail) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImageThumbnail) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImageThumbnail) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ImageThumbnail) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type SystemEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  SystemEvent_Type       `protobuf:"varint,1,opt,name=type,proto3,enum=alexchatapp.SystemEvent_Type" json:"type,omitempty"`
+	// Users the change applies to
+	UserIds []string `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	// New role of the users for OWNERSHIP_TRANSFERRED and ROLE_CHANGED
+	Role          ChatRole `protobuf:"varint,3,opt,name=role,proto3,enum=alexchatapp.ChatRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemEvent) Reset() {
+	*x = SystemEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEvent) ProtoMessage() {}
+
+func (x *SystemEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
+func (*SystemEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SystemEvent) GetType() SystemEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return SystemEvent_UNKNOWN
+}
+
+func (x *SystemEvent) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *SystemEvent) GetRole() ChatRole {
+	if x != nil {
+		return x.Role
+	}
+	return ChatRole_MEMBER
+}
+
+type ReactionSummary struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Emoji string                 `protobuf:"bytes,1,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Count int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// The caller is among the users who reacted
+	ReactedByMe   bool `protobuf:"varint,3,opt,name=reacted_by_me,json=reactedByMe,proto3" json:"reacted_by_me,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionSummary) Reset() {
+	*x = ReactionSummary{}
+	mi := &file_src_proto_chat_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionSummary) ProtoMessage() {}
+
+func (x *ReactionSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionSummary.ProtoReflect.Descriptor instead.
+func (*ReactionSummary) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReactionSummary) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionSummary) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ReactionSummary) GetReactedByMe() bool {
+	if x != nil {
+		return x.ReactedByMe
+	}
+	return false
+}
+
+type TypingEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Set by the server
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Typing        bool   `protobuf:"varint,3,opt,name=typing,proto3" json:"typing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TypingEvent) Reset() {
+	*x = TypingEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TypingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TypingEvent) ProtoMessage() {}
+
+func (x *TypingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TypingEvent.ProtoReflect.Descriptor instead.
+func (*TypingEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TypingEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *TypingEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TypingEvent) GetTyping() bool {
+	if x != nil {
+		return x.Typing
+	}
+	return false
+}
+
+type ReceiptEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Client: acknowledge every message up to this one.
+	// Server: the newest of your messages that reached the status, the older ones changed by the same receipt reached it too
+	MessageId string `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Client: RECEIVED or READ. Server: new aggregated status of the messages
+	Status ChatMessageStatus `protobuf:"varint,3,opt,name=status,proto3,enum=alexchatapp.ChatMessageStatus" json:"status,omitempty"`
+	// Set by the server, the user whose receipt changed the status
+	UserId        string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Timestamp     int64  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiptEvent) Reset() {
+	*x = ReceiptEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptEvent) ProtoMessage() {}
+
+func (x *ReceiptEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptEvent.ProtoReflect.Descriptor instead.
+func (*ReceiptEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReceiptEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ReceiptEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReceiptEvent) GetStatus() ChatMessageStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ChatMessage_SENT
+}
+
+func (x *ReceiptEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReceiptEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type EditEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChatId    string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Text      string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	// Set by the server
+	EditedAt      int64 `protobuf:"varint,4,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditEvent) Reset() {
+	*x = EditEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditEvent) ProtoMessage() {}
+
+func (x *EditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditEvent.ProtoReflect.Descriptor instead.
+func (*EditEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EditEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *EditEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditEvent) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *EditEvent) GetEditedAt() int64 {
+	if x != nil {
+		return x.EditedAt
+	}
+	return 0
+}
+
+type DeleteEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChatId    string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Client only: delete for every participant instead of only for the caller
+	ForEveryone   bool `protobuf:"varint,3,opt,name=for_everyone,json=forEveryone,proto3" json:"for_everyone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEvent) Reset() {
+	*x = DeleteEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEvent) ProtoMessage() {}
+
+func (x *DeleteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEvent.ProtoReflect.Descriptor instead.
+func (*DeleteEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *DeleteEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DeleteEvent) GetForEveryone() bool {
+	if x != nil {
+		return x.ForEveryone
+	}
+	return false
+}
+
+type ReactionEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChatId    string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Set by the server
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Emoji         string `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Removed       bool   `protobuf:"varint,5,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionEvent) Reset() {
+	*x = ReactionEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionEvent) ProtoMessage() {}
+
+func (x *ReactionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionEvent.ProtoReflect.Descriptor instead.
+func (*ReactionEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReactionEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ReactionEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactionEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactionEvent) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionEvent) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+type PinEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChatId    string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// The user who pinned or unpinned the message
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Unpinned      bool   `protobuf:"varint,4,opt,name=unpinned,proto3" json:"unpinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinEvent) Reset() {
+	*x = PinEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinEvent) ProtoMessage() {}
+
+func (x *PinEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinEvent.ProtoReflect.Descriptor instead.
+func (*PinEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PinEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *PinEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *PinEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PinEvent) GetUnpinned() bool {
+	if x != nil {
+		return x.Unpinned
+	}
+	return false
+}
+
+// Sent when the scan of an attachment finished
+type AttachmentEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	State         AttachmentState        `protobuf:"varint,3,opt,name=state,proto3,enum=alexchatapp.AttachmentState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentEvent) Reset() {
+	*x = AttachmentEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentEvent) ProtoMessage() {}
+
+func (x *AttachmentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentEvent.ProtoReflect.Descriptor instead.
+func (*AttachmentEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AttachmentEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *AttachmentEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *AttachmentEvent) GetState() AttachmentState {
+	if x != nil {
+		return x.State
+	}
+	return AttachmentState_READY
+}
+
+// Sent when the previews of the links in a text message were fetched, after it was sent or edited.
+// An edit drops the previews of the earlier text, new ones follow in another event.
+type LinkPreviewEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Previews      []*LinkPreview         `protobuf:"bytes,3,rep,name=previews,proto3" json:"previews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkPreviewEvent) Reset() {
+	*x = LinkPreviewEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkPreviewEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkPreviewEvent) ProtoMessage() {}
+
+func (x *LinkPreviewEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkPreviewEvent.ProtoReflect.Descriptor instead.
+func (*LinkPreviewEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LinkPreviewEvent) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *LinkPreviewEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *LinkPreviewEvent) GetPreviews() []*LinkPreview {
+	if x != nil {
+		return x.Previews
+	}
+	return nil
+}
+
+type Ack struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Id of the created or affected message, if any
+	MessageId     string `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ack) Reset() {
+	*x = Ack{}
+	mi := &file_src_proto_chat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ack) ProtoMessage() {}
+
+func (x *Ack) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ack.ProtoReflect.Descriptor instead.
+func (*Ack) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Ack) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type ErrorEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// gRPC status code
+	Code          int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorEvent) Reset() {
+	*x = ErrorEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorEvent) ProtoMessage() {}
+
+func (x *ErrorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorEvent.ProtoReflect.Descriptor instead.
+func (*ErrorEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ErrorEvent) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ErrorEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ClientEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*ClientEvent_Message
+	//	*ClientEvent_Typing
+	//	*ClientEvent_Receipt
+	//	*ClientEvent_Edit
+	//	*ClientEvent_Delete
+	//	*ClientEvent_Reaction
+	Event         isClientEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientEvent) Reset() {
+	*x = ClientEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientEvent) ProtoMessage() {}
+
+func (x *ClientEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientEvent.ProtoReflect.Descriptor instead.
+func (*ClientEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClientEvent) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ClientEvent) GetEvent() isClientEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ClientEvent) GetMessage() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Event.(*ClientEvent_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *ClientEvent) GetTyping() *TypingEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ClientEvent_Typing); ok {
+			return x.Typing
+		}
+	}
+	return nil
+}
+
+func (x *ClientEvent) GetReceipt() *ReceiptEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ClientEvent_Receipt); ok {
+			return x.Receipt
+		}
+	}
+	return nil
+}
+
+func (x *ClientEvent) GetEdit() *EditEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ClientEvent_Edit); ok {
+			return x.Edit
+		}
+	}
+	return nil
+}
+
+func (x *ClientEvent) GetDelete() *DeleteEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ClientEvent_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+func (x *ClientEvent) GetReaction() *ReactionEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ClientEvent_Reaction); ok {
+			return x.Reaction
+		}
+	}
+	return nil
+}
+
+type isClientEvent_Event interface {
+	isClientEvent_Event()
+}
+
+type ClientEvent_Message struct {
+	Message *ChatMessage `protobuf:"bytes,2,opt,name=message,proto3,oneof"`
+}
+
+type ClientEvent_Typing struct {
+	Typing *TypingEvent `protobuf:"bytes,3,opt,name=typing,proto3,oneof"`
+}
+
+type ClientEvent_Receipt struct {
+	Receipt *ReceiptEvent `protobuf:"bytes,4,opt,name=receipt,proto3,oneof"`
+}
+
+type ClientEvent_Edit struct {
+	Edit *EditEvent `protobuf:"bytes,5,opt,name=edit,proto3,oneof"`
+}
+
+type ClientEvent_Delete struct {
+	Delete *DeleteEvent `protobuf:"bytes,6,opt,name=delete,proto3,oneof"`
+}
+
+type ClientEvent_Reaction struct {
+	Reaction *ReactionEvent `protobuf:"bytes,7,opt,name=reaction,proto3,oneof"`
+}
+
+func (*ClientEvent_Message) isClientEvent_Event() {}
+
+func (*ClientEvent_Typing) isClientEvent_Event() {}
+
+func (*ClientEvent_Receipt) isClientEvent_Event() {}
+
+func (*ClientEvent_Edit) isClientEvent_Event() {}
+
+func (*ClientEvent_Delete) isClientEvent_Event() {}
+
+func (*ClientEvent_Reaction) isClientEvent_Event() {}
+
+type ServerEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Set on Ack and Error replies to a ClientEvent
+	CorrelationId string `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*ServerEvent_Message
+	//	*ServerEvent_Typing
+	//	*ServerEvent_Receipt
+	//	*ServerEvent_Edit
+	//	*ServerEvent_Delete
+	//	*ServerEvent_Reaction
+	//	*ServerEvent_Ack
+	//	*ServerEvent_Error
+	//	*ServerEvent_Pin
+	//	*ServerEvent_Attachment
+	//	*ServerEvent_LinkPreview
+	Event         isServerEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerEvent) Reset() {
+	*x = ServerEvent{}
+	mi := &file_src_proto_chat_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerEvent) ProtoMessage() {}
+
+func (x *ServerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerEvent.ProtoReflect.Descriptor instead.
+func (*ServerEvent) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ServerEvent) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ServerEvent) GetEvent() isServerEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetMessage() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetTyping() *TypingEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Typing); ok {
+			return x.Typing
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetReceipt() *ReceiptEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Receipt); ok {
+			return x.Receipt
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetEdit() *EditEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Edit); ok {
+			return x.Edit
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetDelete() *DeleteEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetReaction() *ReactionEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Reaction); ok {
+			return x.Reaction
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetAck() *Ack {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Ack); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetError() *ErrorEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetPin() *PinEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Pin); ok {
+			return x.Pin
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetAttachment() *AttachmentEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+func (x *ServerEvent) GetLinkPreview() *LinkPreviewEvent {
+	if x != nil {
+		if x, ok := x.Event.(*ServerEvent_LinkPreview); ok {
+			return x.LinkPreview
+		}
+	}
+	return nil
+}
+
+type isServerEvent_Event interface {
+	isServerEvent_Event()
+}
+
+type ServerEvent_Message struct {
+	Message *ChatMessage `protobuf:"bytes,2,opt,name=message,proto3,oneof"`
+}
+
+type ServerEvent_Typing struct {
+	Typing *TypingEvent `protobuf:"bytes,3,opt,name=typing,proto3,oneof"`
+}
+
+type ServerEvent_Receipt struct {
+	Receipt *ReceiptEvent `protobuf:"bytes,4,opt,name=receipt,proto3,oneof"`
+}
+
+type ServerEvent_Edit struct {
+	Edit *EditEvent `protobuf:"bytes,5,opt,name=edit,proto3,oneof"`
+}
+
+type ServerEvent_Delete struct {
+	Delete *DeleteEvent `protobuf:"bytes,6,opt,name=delete,proto3,oneof"`
+}
+
+type ServerEvent_Reaction struct {
+	Reaction *ReactionEvent `protobuf:"bytes,7,opt,name=reaction,proto3,oneof"`
+}
+
+type ServerEvent_Ack struct {
+	Ack *Ack `protobuf:"bytes,8,opt,name=ack,proto3,oneof"`
+}
+
+type ServerEvent_Error struct {
+	Error *ErrorEvent `protobuf:"bytes,9,opt,name=error,proto3,oneof"`
+}
+
+type ServerEvent_Pin struct {
+	Pin *PinEvent `protobuf:"bytes,10,opt,name=pin,proto3,oneof"`
+}
+
+type ServerEvent_Attachment struct {
+	Attachment *AttachmentEvent `protobuf:"bytes,11,opt,name=attachment,proto3,oneof"`
+}
+
+type ServerEvent_LinkPreview struct {
+	LinkPreview *LinkPreviewEvent `protobuf:"bytes,12,opt,name=link_preview,json=linkPreview,proto3,oneof"`
+}
+
+func (*ServerEvent_Message) isServerEvent_Event() {}
+
+func (*ServerEvent_Typing) isServerEvent_Event() {}
+
+func (*ServerEvent_Receipt) isServerEvent_Event() {}
+
+func (*ServerEvent_Edit) isServerEvent_Event() {}
+
+func (*ServerEvent_Delete) isServerEvent_Event() {}
+
+func (*ServerEvent_Reaction) isServerEvent_Event() {}
+
+func (*ServerEvent_Ack) isServerEvent_Event() {}
+
+func (*ServerEvent_Error) isServerEvent_Event() {}
+
+func (*ServerEvent_Pin) isServerEvent_Event() {}
+
+func (*ServerEvent_Attachment) isServerEvent_Event() {}
+
+func (*ServerEvent_LinkPreview) isServerEvent_Event() {}
+
+type GetChatsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Ignored, chats of the authenticated user are returned
+	Count  int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Opaque token from GetChatsResponse.next_cursor
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatsRequest) Reset() {
+	*x = GetChatsRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatsRequest) ProtoMessage() {}
+
+func (x *GetChatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatsRequest.ProtoReflect.Descriptor instead.
+func (*GetChatsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetChatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetChatsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetChatsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type MessagePreview struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	SenderId  string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// Shortened text, or a placeholder for media messages
+	Text          string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Timestamp     int64  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessagePreview) Reset() {
+	*x = MessagePreview{}
+	mi := &file_src_proto_chat_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessagePreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessagePreview) ProtoMessage() {}
+
+func (x *MessagePreview) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessagePreview.ProtoReflect.Descriptor instead.
+func (*MessagePreview) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MessagePreview) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MessagePreview) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type Chat struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Direct chats are named after the other participant
+	Name         string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description  *string         `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	LastMessage  *MessagePreview `protobuf:"bytes,4,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	LastActivity int64           `protobuf:"varint,5,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	UnreadCount  int32           `protobuf:"varint,6,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	MentionCount int32           `protobuf:"varint,7,opt,name=mention_count,json=mentionCount,proto3" json:"mention_count,omitempty"`
+	// Unread replies in the threads the caller follows
+	ThreadUnreadCount int32    `protobuf:"varint,8,opt,name=thread_unread_count,json=threadUnreadCount,proto3" json:"thread_unread_count,omitempty"`
+	Type              ChatType `protobuf:"varint,9,opt,name=type,proto3,enum=alexchatapp.ChatType" json:"type,omitempty"`
+	// The other participant of a direct chat
+	DirectUserId  string `protobuf:"bytes,10,opt,name=direct_user_id,json=directUserId,proto3" json:"direct_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Chat) Reset() {
+	*x = Chat{}
+	mi := &file_src_proto_chat_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chat) ProtoMessage() {}
+
+func (x *Chat) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
+func (*Chat) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Chat) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Chat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chat) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Chat) GetLastMessage() *MessagePreview {
+	if x != nil {
+		return x.LastMessage
+	}
+	return nil
+}
+
+func (x *Chat) GetLastActivity() int64 {
+	if x != nil {
+		return x.LastActivity
+	}
+	return 0
+}
+
+func (x *Chat) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *Chat) GetMentionCount() int32 {
+	if x != nil {
+		return x.MentionCount
+	}
+	return 0
+}
+
+func (x *Chat) GetThreadUnreadCount() int32 {
+	if x != nil {
+		return x.ThreadUnreadCount
+	}
+	return 0
+}
+
+func (x *Chat) GetType() ChatType {
+	if x != nil {
+		return x.Type
+	}
+	return ChatType_GROUP
+}
+
+func (x *Chat) GetDirectUserId() string {
+	if x != nil {
+		return x.DirectUserId
+	}
+	return ""
+}
+
+type GetChatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sorted by last activity, newest first
+	Chats         []*Chat `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	NextCursor    string  `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool    `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatsResponse) Reset() {
+	*x = GetChatsResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatsResponse) ProtoMessage() {}
+
+func (x *GetChatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatsResponse.ProtoReflect.Descriptor instead.
+func (*GetChatsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetChatsResponse) GetChats() []*Chat {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+func (x *GetChatsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetChatsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type GetMessagesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChatId          string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Count           int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	BeforeTimestamp int64                  `protobuf:"varint,3,opt,name=before_timestamp,json=beforeTimestamp,proto3" json:"before_timestamp,omitempty"` // Deprecated: use cursor
+	// Opaque token from GetMessagesResponse, empty cursor starts from the latest messages
+	Cursor    string                       `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Direction GetMessagesRequest_Direction `protobuf:"varint,5,opt,name=direction,proto3,enum=alexchatapp.GetMessagesRequest_Direction" json:"direction,omitempty"`
+	// Returns a window of messages around this message, cursor and direction are ignored
+	AroundMessageId *string `protobuf:"bytes,6,opt,name=around_message_id,json=aroundMessageId,proto3,oneof" json:"around_message_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetMessagesRequest) Reset() {
+	*x = GetMessagesRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessagesRequest) ProtoMessage() {}
+
+func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
+func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GetMessagesRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetBeforeTimestamp() int64 {
+	if x != nil {
+		return x.BeforeTimestamp
+	}
+	return 0
+}
+
+func (x *GetMessagesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetMessagesRequest) GetDirection() GetMessagesRequest_Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return GetMessagesRequest_BACKWARD
+}
+
+func (x *GetMessagesRequest) GetAroundMessageId() string {
+	if x != nil && x.AroundMessageId != nil {
+		return *x.AroundMessageId
+	}
+	return ""
+}
+
+type GetMessagesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Messages []*ChatMessage         `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// Pass with BACKWARD direction to load older messages
+	PrevCursor string `protobuf:"bytes,2,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
+	// Pass with FORWARD direction to load newer messages
+	NextCursor    string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMoreBefore bool   `protobuf:"varint,4,opt,name=has_more_before,json=hasMoreBefore,proto3" json:"has_more_before,omitempty"`
+	HasMoreAfter  bool   `protobuf:"varint,5,opt,name=has_more_after,json=hasMoreAfter,proto3" json:"has_more_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessagesResponse) Reset() {
+	*x = GetMessagesResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessagesResponse) ProtoMessage() {}
+
+func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
+func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetMessagesResponse) GetMessages() []*ChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *GetMessagesResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
+func (x *GetMessagesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetMessagesResponse) GetHasMoreBefore() bool {
+	if x != nil {
+		return x.HasMoreBefore
+	}
+	return false
+}
+
+func (x *GetMessagesResponse) GetHasMoreAfter() bool {
+	if x != nil {
+		return x.HasMoreAfter
+	}
+	return false
+}
+
+type SearchMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Words to find, supports "quoted phrases", OR and -excluded words
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Searches every chat of the caller when empty
+	ChatId   string `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	SenderId string `protobuf:"bytes,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// Unix milliseconds, 0 leaves the range open
+	After  int64 `protobuf:"varint,4,opt,name=after,proto3" json:"after,omitempty"`
+	Before int64 `protobuf:"varint,5,opt,name=before,proto3" json:"before,omitempty"`
+	Count  int32 `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	// Opaque token from SearchMessagesResponse.next_cursor
+	Cursor        string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchMessagesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetAfter() int64 {
+	if x != nil {
+		return x.After
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetBefore() int64 {
+	if x != nil {
+		return x.Before
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *ChatMessage           `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Fragments of the HTML escaped text with matched words wrapped in <mark></mark>, safe to render as HTML
+	Snippet       string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_src_proto_chat_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SearchResult) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first
+	Results       []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextCursor    string          `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool            `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SearchMessagesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchMessagesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SearchMessagesResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type CreateChatRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Channel subscribers when creating a channel
+	ParticipantsIds []string `protobuf:"bytes,2,rep,name=participants_ids,json=participantsIds,proto3" json:"participants_ids,omitempty"`
+	// GROUP or CHANNEL, direct chats are created with GetOrCreateDirectChat
+	Type          ChatType `protobuf:"varint,3,opt,name=type,proto3,enum=alexchatapp.ChatType" json:"type,omitempty"`
+	Description   string   `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChatRequest) Reset() {
+	*x = CreateChatRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChatRequest) ProtoMessage() {}
+
+func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChatRequest.ProtoReflect.Descriptor instead.
+func (*CreateChatRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateChatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateChatRequest) GetParticipantsIds() []string {
+	if x != nil {
+		return x.ParticipantsIds
+	}
+	return nil
+}
+
+func (x *CreateChatRequest) GetType() ChatType {
+	if x != nil {
+		return x.Type
+	}
+	return ChatType_GROUP
+}
+
+func (x *CreateChatRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CreateChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateChatResponse) Reset() {
+	*x = CreateChatResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChatResponse) ProtoMessage() {}
+
+func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChatResponse.ProtoReflect.Descriptor instead.
+func (*CreateChatResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateChatResponse) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type GetOrCreateDirectChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetUserId  string                 `protobuf:"bytes,1,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrCreateDirectChatRequest) Reset() {
+	*x = GetOrCreateDirectChatRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrCreateDirectChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrCreateDirectChatRequest) ProtoMessage() {}
+
+func (x *GetOrCreateDirectChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrCreateDirectChatRequest.ProtoReflect.Descriptor instead.
+func (*GetOrCreateDirectChatRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetOrCreateDirectChatRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+type GetOrCreateDirectChatResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Chat  *Chat                  `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
+	// False if the chat already existed
+	Created       bool `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrCreateDirectChatResponse) Reset() {
+	*x = GetOrCreateDirectChatResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrCreateDirectChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrCreateDirectChatResponse) ProtoMessage() {}
+
+func (x *GetOrCreateDirectChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrCreateDirectChatResponse.ProtoReflect.Descriptor instead.
+func (*GetOrCreateDirectChatResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetOrCreateDirectChatResponse) GetChat() *Chat {
+	if x != nil {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *GetOrCreateDirectChatResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+type MarkDeliveredRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UpToMessageId string                 `protobuf:"bytes,2,opt,name=up_to_message_id,json=upToMessageId,proto3" json:"up_to_message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkDeliveredRequest) Reset() {
+	*x = MarkDeliveredRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkDeliveredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkDeliveredRequest) ProtoMessage() {}
+
+func (x *MarkDeliveredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkDeliveredRequest.ProtoReflect.Descriptor instead.
+func (*MarkDeliveredRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MarkDeliveredRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MarkDeliveredRequest) GetUpToMessageId() string {
+	if x != nil {
+		return x.UpToMessageId
+	}
+	return ""
+}
+
+type MarkReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UpToMessageId string                 `protobuf:"bytes,2,opt,name=up_to_message_id,json=upToMessageId,proto3" json:"up_to_message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkReadRequest) Reset() {
+	*x = MarkReadRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkReadRequest) ProtoMessage() {}
+
+func (x *MarkReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkReadRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *MarkReadRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MarkReadRequest) GetUpToMessageId() string {
+	if x != nil {
+		return x.UpToMessageId
+	}
+	return ""
+}
+
+type ReceiptsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of messages whose status changed
+	UpdatedCount  int32 `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiptsResponse) Reset() {
+	*x = ReceiptsResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiptsResponse) ProtoMessage() {}
+
+func (x *ReceiptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiptsResponse.ProtoReflect.Descriptor instead.
+func (*ReceiptsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReceiptsResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+type MessageReceipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeliveredAt   int64                  `protobuf:"varint,2,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	ReadAt        int64                  `protobuf:"varint,3,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageReceipt) Reset() {
+	*x = MessageReceipt{}
+	mi := &file_src_proto_chat_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReceipt) ProtoMessage() {}
+
+func (x *MessageReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReceipt.ProtoReflect.Descriptor instead.
+func (*MessageReceipt) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MessageReceipt) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MessageReceipt) GetDeliveredAt() int64 {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return 0
+}
+
+func (x *MessageReceipt) GetReadAt() int64 {
+	if x != nil {
+		return x.ReadAt
+	}
+	return 0
+}
+
+type GetMessageReceiptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageReceiptsRequest) Reset() {
+	*x = GetMessageReceiptsRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageReceiptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageReceiptsRequest) ProtoMessage() {}
+
+func (x *GetMessageReceiptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageReceiptsRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageReceiptsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetMessageReceiptsRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GetMessageReceiptsRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type GetMessageReceiptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipts      []*MessageReceipt      `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageReceiptsResponse) Reset() {
+	*x = GetMessageReceiptsResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageReceiptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageReceiptsResponse) ProtoMessage() {}
+
+func (x *GetMessageReceiptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageReceiptsResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageReceiptsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetMessageReceiptsResponse) GetReceipts() []*MessageReceipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+type EditMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *EditMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type EditMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *ChatMessage           `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageResponse) Reset() {
+	*x = EditMessageResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageResponse) ProtoMessage() {}
+
+func (x *EditMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageResponse.ProtoReflect.Descriptor instead.
+func (*EditMessageResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *EditMessageResponse) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type DeleteMessageRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChatId    string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// Delete for every participant instead of only for the caller
+	ForEveryone   bool `protobuf:"varint,3,opt,name=for_everyone,json=forEveryone,proto3" json:"for_everyone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *DeleteMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DeleteMessageRequest) GetForEveryone() bool {
+	if x != nil {
+		return x.ForEveryone
+	}
+	return false
+}
+
+type DeleteMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageResponse) Reset() {
+	*x = DeleteMessageResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageResponse) ProtoMessage() {}
+
+func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{41}
+}
+
+type MessageEdit struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	EditorId string                 `protobuf:"bytes,1,opt,name=editor_id,json=editorId,proto3" json:"editor_id,omitempty"`
+	// Text before the edit
+	Text          string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	EditedAt      int64  `protobuf:"varint,3,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageEdit) Reset() {
+	*x = MessageEdit{}
+	mi := &file_src_proto_chat_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageEdit) ProtoMessage() {}
+
+func (x *MessageEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageEdit.ProtoReflect.Descriptor instead.
+func (*MessageEdit) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MessageEdit) GetEditorId() string {
+	if x != nil {
+		return x.EditorId
+	}
+	return ""
+}
+
+func (x *MessageEdit) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MessageEdit) GetEditedAt() int64 {
+	if x != nil {
+		return x.EditedAt
+	}
+	return 0
+}
+
+type GetMessageEditsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageEditsRequest) Reset() {
+	*x = GetMessageEditsRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageEditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageEditsRequest) ProtoMessage() {}
+
+func (x *GetMessageEditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageEditsRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageEditsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetMessageEditsRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GetMessageEditsRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type GetMessageEditsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edits         []*MessageEdit         `protobuf:"bytes,1,rep,name=edits,proto3" json:"edits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMessageEditsResponse) Reset() {
+	*x = GetMessageEditsResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMessageEditsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMessageEditsResponse) ProtoMessage() {}
+
+func (x *GetMessageEditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMessageEditsResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageEditsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetMessageEditsResponse) GetEdits() []*MessageEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+type ReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Emoji         string                 `protobuf:"bytes,3,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionRequest) Reset() {
+	*x = ReactionRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionRequest) ProtoMessage() {}
+
+func (x *ReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionRequest.ProtoReflect.Descriptor instead.
+func (*ReactionRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReactionRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *ReactionRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type ReactionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reactions of the message after the change
+	Reactions     []*ReactionSummary `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionResponse) Reset() {
+	*x = ReactionResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionResponse) ProtoMessage() {}
+
+func (x *ReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionResponse.ProtoReflect.Descriptor instead.
+func (*ReactionResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ReactionResponse) GetReactions() []*ReactionSummary {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type GetThreadRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// A reply can be passed as well, its thread is returned
+	RootMessageId string `protobuf:"bytes,2,opt,name=root_message_id,json=rootMessageId,proto3" json:"root_message_id,omitempty"`
+	Count         int32  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// Opaque token from GetThreadResponse.next_cursor, empty cursor starts from the first reply
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadRequest) Reset() {
+	*x = GetThreadRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRequest) ProtoMessage() {}
+
+func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetThreadRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *GetThreadRequest) GetRootMessageId() string {
+	if x != nil {
+		return x.RootMessageId
+	}
+	return ""
+}
+
+func (x *GetThreadRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetThreadRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetThreadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Root  *ChatMessage           `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// Oldest first
+	Replies       []*ChatMessage `protobuf:"bytes,2,rep,name=replies,proto3" json:"replies,omitempty"`
+	NextCursor    string         `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasMore       bool           `protobuf:"varint,4,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	UnreadCount   int32          `protobuf:"varint,5,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadResponse) Reset() {
+	*x = GetThreadResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadResponse) ProtoMessage() {}
+
+func (x *GetThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadResponse.ProtoReflect.Descriptor instead.
+func (*GetThreadResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetThreadResponse) GetRoot() *ChatMessage {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetReplies() []*ChatMessage {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+func (x *GetThreadResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetThreadResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *GetThreadResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type MarkThreadReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RootMessageId string                 `protobuf:"bytes,2,opt,name=root_message_id,json=rootMessageId,proto3" json:"root_message_id,omitempty"`
+	UpToMessageId string                 `protobuf:"bytes,3,opt,name=up_to_message_id,json=upToMessageId,proto3" json:"up_to_message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkThreadReadRequest) Reset() {
+	*x = MarkThreadReadRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkThreadReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkThreadReadRequest) ProtoMessage() {}
+
+func (x *MarkThreadReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkThreadReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkThreadReadRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *MarkThreadReadRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *MarkThreadReadRequest) GetRootMessageId() string {
+	if x != nil {
+		return x.RootMessageId
+	}
+	return ""
+}
+
+func (x *MarkThreadReadRequest) GetUpToMessageId() string {
+	if x != nil {
+		return x.UpToMessageId
+	}
+	return ""
+}
+
+type MarkThreadReadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UnreadCount   int32                  `protobuf:"varint,1,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkThreadReadResponse) Reset() {
+	*x = MarkThreadReadResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkThreadReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkThreadReadResponse) ProtoMessage() {}
+
+func (x *MarkThreadReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkThreadReadResponse.ProtoReflect.Descriptor instead.
+func (*MarkThreadReadResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MarkThreadReadResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+type Participant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          ChatRole               `protobuf:"varint,2,opt,name=role,proto3,enum=alexchatapp.ChatRole" json:"role,omitempty"`
+	JoinedAt      int64                  `protobuf:"varint,3,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Participant) Reset() {
+	*x = Participant{}
+	mi := &file_src_proto_chat_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Participant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Participant) ProtoMessage() {}
+
+func (x *Participant) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Participant.ProtoReflect.Descriptor instead.
+func (*Participant) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Participant) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Participant) GetRole() ChatRole {
+	if x != nil {
+		return x.Role
+	}
+	return ChatRole_MEMBER
+}
+
+func (x *Participant) GetJoinedAt() int64 {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return 0
+}
+
+type GetParticipantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetParticipantsRequest) Reset() {
+	*x = GetParticipantsRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetParticipantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetParticipantsRequest) ProtoMessage() {}
+
+func (x *GetParticipantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetParticipantsRequest.ProtoReflect.Descriptor instead.
+func (*GetParticipantsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetParticipantsRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type GetParticipantsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// In the order they joined
+	Participants  []*Participant `protobuf:"bytes,1,rep,name=participants,proto3" json:"participants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetParticipantsResponse) Reset() {
+	*x = GetParticipantsResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetParticipantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetParticipantsResponse) ProtoMessage() {}
+
+func (x *GetParticipantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetParticipantsResponse.ProtoReflect.Descriptor instead.
+func (*GetParticipantsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *GetParticipantsResponse) GetParticipants() []*Participant {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+type AddParticipantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddParticipantsRequest) Reset() {
+	*x = AddParticipantsRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddParticipantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddParticipantsRequest) ProtoMessage() {}
+
+func (x *AddParticipantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddParticipantsRequest.ProtoReflect.Descriptor instead.
+func (*AddParticipantsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *AddParticipantsRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *AddParticipantsRequest) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type AddParticipantsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Users who were not members before
+	AddedUserIds  []string `protobuf:"bytes,1,rep,name=added_user_ids,json=addedUserIds,proto3" json:"added_user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddParticipantsResponse) Reset() {
+	*x = AddParticipantsResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddParticipantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddParticipantsResponse) ProtoMessage() {}
+
+func (x *AddParticipantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddParticipantsResponse.ProtoReflect.Descriptor instead.
+func (*AddParticipantsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AddParticipantsResponse) GetAddedUserIds() []string {
+	if x != nil {
+		return x.AddedUserIds
+	}
+	return nil
+}
+
+type RemoveParticipantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveParticipantRequest) Reset() {
+	*x = RemoveParticipantRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveParticipantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveParticipantRequest) ProtoMessage() {}
+
+func (x *RemoveParticipantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveParticipantRequest.ProtoReflect.Descriptor instead.
+func (*RemoveParticipantRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RemoveParticipantRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *RemoveParticipantRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveParticipantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveParticipantResponse) Reset() {
+	*x = RemoveParticipantResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveParticipantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveParticipantResponse) ProtoMessage() {}
+
+func (x *RemoveParticipantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveParticipantResponse.ProtoReflect.Descriptor instead.
+func (*RemoveParticipantResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{57}
+}
+
+type LeaveChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveChatRequest) Reset() {
+	*x = LeaveChatRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveChatRequest) ProtoMessage() {}
+
+func (x *LeaveChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveChatRequest.ProtoReflect.Descriptor instead.
+func (*LeaveChatRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *LeaveChatRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type LeaveChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveChatResponse) Reset() {
+	*x = LeaveChatResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveChatResponse) ProtoMessage() {}
+
+func (x *LeaveChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveChatResponse.ProtoReflect.Descriptor instead.
+func (*LeaveChatResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{59}
+}
+
+type TransferOwnershipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	NewOwnerId    string                 `protobuf:"bytes,2,opt,name=new_owner_id,json=newOwnerId,proto3" json:"new_owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipRequest) Reset() {
+	*x = TransferOwnershipRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *TransferOwnershipRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetNewOwnerId() string {
+	if x != nil {
+		return x.NewOwnerId
+	}
+	return ""
+}
+
+type TransferOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipResponse) Reset() {
+	*x = TransferOwnershipResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipResponse) ProtoMessage() {}
+
+func (x *TransferOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{61}
+}
+
+type SetParticipantRoleRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// ADMIN or MEMBER, ownership changes with TransferOwnership
+	Role          ChatRole `protobuf:"varint,3,opt,name=role,proto3,enum=alexchatapp.ChatRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetParticipantRoleRequest) Reset() {
+	*x = SetParticipantRoleRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetParticipantRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetParticipantRoleRequest) ProtoMessage() {}
+
+func (x *SetParticipantRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetParticipantRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetParticipantRoleRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *SetParticipantRoleRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *SetParticipantRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetParticipantRoleRequest) GetRole() ChatRole {
+	if x != nil {
+		return x.Role
+	}
+	return ChatRole_MEMBER
+}
+
+type SetParticipantRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetParticipantRoleResponse) Reset() {
+	*x = SetParticipantRoleResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetParticipantRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetParticipantRoleResponse) ProtoMessage() {}
+
+func (x *SetParticipantRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetParticipantRoleResponse.ProtoReflect.Descriptor instead.
+func (*SetParticipantRoleResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{63}
+}
+
+type PinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageRequest) Reset() {
+	*x = PinMessageRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageRequest) ProtoMessage() {}
+
+func (x *PinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageRequest.ProtoReflect.Descriptor instead.
+func (*PinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *PinMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type PinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageResponse) Reset() {
+	*x = PinMessageResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageResponse) ProtoMessage() {}
+
+func (x *PinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageResponse.ProtoReflect.Descriptor instead.
+func (*PinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{65}
+}
+
+type UnpinMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	MessageId     string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageRequest) Reset() {
+	*x = UnpinMessageRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageRequest) ProtoMessage() {}
+
+func (x *UnpinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageRequest.ProtoReflect.Descriptor instead.
+func (*UnpinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *UnpinMessageRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *UnpinMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type UnpinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageResponse) Reset() {
+	*x = UnpinMessageResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageResponse) ProtoMessage() {}
+
+func (x *UnpinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageResponse.ProtoReflect.Descriptor instead.
+func (*UnpinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{67}
+}
+
+type ListPinnedMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesRequest) Reset() {
+	*x = ListPinnedMessagesRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesRequest) ProtoMessage() {}
+
+func (x *ListPinnedMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListPinnedMessagesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type PinnedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *ChatMessage           `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	PinnedBy      string                 `protobuf:"bytes,2,opt,name=pinned_by,json=pinnedBy,proto3" json:"pinned_by,omitempty"`
+	PinnedAt      int64                  `protobuf:"varint,3,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedMessage) Reset() {
+	*x = PinnedMessage{}
+	mi := &file_src_proto_chat_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedMessage) ProtoMessage() {}
+
+func (x *PinnedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedMessage.ProtoReflect.Descriptor instead.
+func (*PinnedMessage) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *PinnedMessage) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *PinnedMessage) GetPinnedBy() string {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return ""
+}
+
+func (x *PinnedMessage) GetPinnedAt() int64 {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return 0
+}
+
+type ListPinnedMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most recently pinned first
+	Pins          []*PinnedMessage `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesResponse) Reset() {
+	*x = ListPinnedMessagesResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesResponse) ProtoMessage() {}
+
+func (x *ListPinnedMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListPinnedMessagesResponse) GetPins() []*PinnedMessage {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+type Invite struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId    string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Code      string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	CreatorId string                 `protobuf:"bytes,4,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	CreatedAt int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 0 if the invite never expires
+	ExpiresAt int64 `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// 0 means unlimited
+	MaxUses          int32 `protobuf:"varint,7,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Uses             int32 `protobuf:"varint,8,opt,name=uses,proto3" json:"uses,omitempty"`
+	RequiresApproval bool  `protobuf:"varint,9,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Invite) Reset() {
+	*x = Invite{}
+	mi := &file_src_proto_chat_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invite) ProtoMessage() {}
+
+func (x *Invite) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invite.ProtoReflect.Descriptor instead.
+func (*Invite) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *Invite) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invite) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *Invite) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Invite) GetCreatorId() string {
+	if x != nil {
+		return x.CreatorId
+	}
+	return ""
+}
+
+func (x *Invite) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Invite) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Invite) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Invite) GetUses() int32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+func (x *Invite) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+type CreateInviteRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// Unix milliseconds, 0 for an invite that never expires
+	ExpiresAt int64 `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// 0 means unlimited
+	MaxUses int32 `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Users who join wait in the join request queue until an admin approves them
+	RequiresApproval bool `protobuf:"varint,4,opt,name=requires_approval,json=requiresApproval,proto3" json:"requires_approval,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CreateInviteRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetRequiresApproval() bool {
+	if x != nil {
+		return x.RequiresApproval
+	}
+	return false
+}
+
+type CreateInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invite        *Invite                `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteResponse) Reset() {
+	*x = CreateInviteResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteResponse) ProtoMessage() {}
+
+func (x *CreateInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteResponse.ProtoReflect.Descriptor instead.
+func (*CreateInviteResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CreateInviteResponse) GetInvite() *Invite {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+type RevokeInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	InviteId      string                 `protobuf:"bytes,2,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteRequest) Reset() {
+	*x = RevokeInviteRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteRequest) ProtoMessage() {}
+
+func (x *RevokeInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInviteRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RevokeInviteRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *RevokeInviteRequest) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+type RevokeInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteResponse) Reset() {
+	*x = RevokeInviteResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteResponse) ProtoMessage() {}
+
+func (x *RevokeInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteResponse.ProtoReflect.Descriptor instead.
+func (*RevokeInviteResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{75}
+}
+
+type ListInvitesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesRequest) Reset() {
+	*x = ListInvitesRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesRequest) ProtoMessage() {}
+
+func (x *ListInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListInvitesRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+type ListInvitesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Invites that are not revoked, newest first
+	Invites       []*Invite `protobuf:"bytes,1,rep,name=invites,proto3" json:"invites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesResponse) Reset() {
+	*x = ListInvitesResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesResponse) ProtoMessage() {}
+
+func (x *ListInvitesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitesResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListInvitesResponse) GetInvites() []*Invite {
+	if x != nil {
+		return x.Invites
+	}
+	return nil
+}
+
+type JoinByInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinByInviteRequest) Reset() {
+	*x = JoinByInviteRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinByInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinByInviteRequest) ProtoMessage() {}
+
+func (x *JoinByInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinByInviteRequest.ProtoReflect.Descriptor instead.
+func (*JoinByInviteRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *JoinByInviteRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type JoinByInviteResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ChatId string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	// The caller waits for an admin to approve the join request
+	PendingApproval bool `protobuf:"varint,2,opt,name=pending_approval,json=pendingApproval,proto3" json:"pending_approval,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *JoinByInviteResponse) Reset() {
+	*x = JoinByInviteResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinByInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinByInviteResponse) ProtoMessage() {}
+
+func (x *JoinByInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinByInviteResponse.ProtoReflect.Descriptor instead.
+func (*JoinByInviteResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *JoinByInviteResponse) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
+
+func (x *JoinByInviteResponse) GetPendingApproval() bool {
+	if x != nil {
+		return x.PendingApproval
+	}
+	return false
+}
+
+type JoinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChatId        string                 `protobuf:"bytes,2,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InviteId      string                 `protobuf:"bytes,4,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequest) ProtoMessage() {}
+
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *JoinRequest) GetId() string {
 	if x != nil {
-		return x.MessageStatus
+		return x.Id
 	}
-	return ChatMessage_SENT
+	return ""
 }
 
-func (x *ChatMessage) GetContent() isChatMessage_Content {
+func (x *JoinRequest) GetChatId() string {
 	if x != nil {
-		return x.Content
+		return x.ChatId
 	}
-	return nil
+	return ""
 }
 
-func (x *ChatMessage) GetText() string {
+func (x *JoinRequest) GetUserId() string {
 	if x != nil {
-		if x, ok := x.Content.(*ChatMessage_Text); ok {
-			return x.Text
-		}
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *ChatMessage) GetAudioData() []byte {
+func (x *JoinRequest) GetInviteId() string {
 	if x != nil {
-		if x, ok := x.Content.(*ChatMessage_AudioData); ok {
-			return x.AudioData
-		}
+		return x.InviteId
 	}
-	return nil
+	return ""
 }
 
-func (x *ChatMessage) GetImageData() []byte {
+func (x *JoinRequest) GetCreatedAt() int64 {
 	if x != nil {
-		if x, ok := x.Content.(*ChatMessage_ImageData); ok {
-			return x.ImageData
-		}
+		return x.CreatedAt
 	}
-	return nil
+	return 0
 }
 
-type isChatMessage_Content interface {
-	isChatMessage_Content()
+type ListJoinRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type ChatMessage_Text struct {
-	Text string `protobuf:"bytes,6,opt,name=text,proto3,oneof"`
+func (x *ListJoinRequestsRequest) Reset() {
+	*x = ListJoinRequestsRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type ChatMessage_AudioData struct {
-	AudioData []byte `protobuf:"bytes,7,opt,name=audio_data,json=audioData,proto3,oneof"`
+func (x *ListJoinRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type ChatMessage_ImageData struct {
-	ImageData []byte `protobuf:"bytes,8,opt,name=image_data,json=imageData,proto3,oneof"`
-}
+func (*ListJoinRequestsRequest) ProtoMessage() {}
 
-func (*ChatMessage_Text) isChatMessage_Content() {}
+func (x *ListJoinRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*ChatMessage_AudioData) isChatMessage_Content() {}
+// Deprecated: Use ListJoinRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{81}
+}
 
-func (*ChatMessage_ImageData) isChatMessage_Content() {}
+func (x *ListJoinRequestsRequest) GetChatId() string {
+	if x != nil {
+		return x.ChatId
+	}
+	return ""
+}
 
-type GetChatsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+type ListJoinRequestsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pending requests, oldest first
+	Requests      []*JoinRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChatsRequest) Reset() {
-	*x = GetChatsRequest{}
-	mi := &file_src_proto_chat_proto_msgTypes[1]
+func (x *ListJoinRequestsResponse) Reset() {
+	*x = ListJoinRequestsResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChatsRequest) String() string {
+func (x *ListJoinRequestsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatsRequest) ProtoMessage() {}
+func (*ListJoinRequestsResponse) ProtoMessage() {}
 
-func (x *GetChatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_chat_proto_msgTypes[1]
+func (x *ListJoinRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -240,42 +5587,41 @@ func (x *GetChatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatsRequest.ProtoReflect.Descriptor instead.
-func (*GetChatsRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_chat_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListJoinRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *GetChatsRequest) GetUserId() string {
+func (x *ListJoinRequestsResponse) GetRequests() []*JoinRequest {
 	if x != nil {
-		return x.UserId
+		return x.Requests
 	}
-	return ""
+	return nil
 }
 
-type Chat struct {
+type DecideJoinRequestRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	RequestId     string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Chat) Reset() {
-	*x = Chat{}
-	mi := &file_src_proto_chat_proto_msgTypes[2]
+func (x *DecideJoinRequestRequest) Reset() {
+	*x = DecideJoinRequestRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Chat) String() string {
+func (x *DecideJoinRequestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Chat) ProtoMessage() {}
+func (*DecideJoinRequestRequest) ProtoMessage() {}
 
-func (x *Chat) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_chat_proto_msgTypes[2]
+func (x *DecideJoinRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,54 +5632,46 @@ func (x *Chat) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Chat.ProtoReflect.Descriptor instead.
-func (*Chat) Descriptor() ([]byte, []int) {
-	return file_src_proto_chat_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DecideJoinRequestRequest.ProtoReflect.Descriptor instead.
+func (*DecideJoinRequestRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *Chat) GetId() string {
+func (x *DecideJoinRequestRequest) GetChatId() string {
 	if x != nil {
-		return x.Id
+		return x.ChatId
 	}
 	return ""
 }
 
-func (x *Chat) GetName() string {
+func (x *DecideJoinRequestRequest) GetRequestId() string {
 	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Chat) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+		return x.RequestId
 	}
 	return ""
 }
 
-type GetChatsResponse struct {
+type DecideJoinRequestResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChatsResponse) Reset() {
-	*x = GetChatsResponse{}
-	mi := &file_src_proto_chat_proto_msgTypes[3]
+func (x *DecideJoinRequestResponse) Reset() {
+	*x = DecideJoinRequestResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChatsResponse) String() string {
+func (x *DecideJoinRequestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChatsResponse) ProtoMessage() {}
+func (*DecideJoinRequestResponse) ProtoMessage() {}
 
-func (x *GetChatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_chat_proto_msgTypes[3]
+func (x *DecideJoinRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -344,42 +5682,33 @@ func (x *GetChatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChatsResponse.ProtoReflect.Descriptor instead.
-func (*GetChatsResponse) Descriptor() ([]byte, []int) {
-	return file_src_proto_chat_proto_rawDescGZIP(), []int{3}
-}
-
-func (x *GetChatsResponse) GetChats() []*Chat {
-	if x != nil {
-		return x.Chats
-	}
-	return nil
+// Deprecated: Use DecideJoinRequestResponse.ProtoReflect.Descriptor instead.
+func (*DecideJoinRequestResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{84}
 }
 
-type GetMessagesRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ChatId          string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
-	Count           int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
-	BeforeTimestamp int64                  `protobuf:"varint,3,opt,name=before_timestamp,json=beforeTimestamp,proto3" json:"before_timestamp,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMessagesRequest) Reset() {
-	*x = GetMessagesRequest{}
-	mi := &file_src_proto_chat_proto_msgTypes[4]
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMessagesRequest) String() string {
+func (x *SubscribeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMessagesRequest) ProtoMessage() {}
+func (*SubscribeRequest) ProtoMessage() {}
 
-func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_chat_proto_msgTypes[4]
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -390,54 +5719,39 @@ func (x *GetMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMessagesRequest.ProtoReflect.Descriptor instead.
-func (*GetMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_chat_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{85}
 }
 
-func (x *GetMessagesRequest) GetChatId() string {
+func (x *SubscribeRequest) GetChatId() string {
 	if x != nil {
 		return x.ChatId
 	}
 	return ""
 }
 
-func (x *GetMessagesRequest) GetCount() int32 {
-	if x != nil {
-		return x.Count
-	}
-	return 0
-}
-
-func (x *GetMessagesRequest) GetBeforeTimestamp() int64 {
-	if x != nil {
-		return x.BeforeTimestamp
-	}
-	return 0
-}
-
-type GetMessagesResponse struct {
+type SubscribeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*ChatMessage         `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMessagesResponse) Reset() {
-	*x = GetMessagesResponse{}
-	mi := &file_src_proto_chat_proto_msgTypes[5]
+func (x *SubscribeResponse) Reset() {
+	*x = SubscribeResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMessagesResponse) String() string {
+func (x *SubscribeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMessagesResponse) ProtoMessage() {}
+func (*SubscribeResponse) ProtoMessage() {}
 
-func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_chat_proto_msgTypes[5]
+func (x *SubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -448,41 +5762,33 @@ func (x *GetMessagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMessagesResponse.ProtoReflect.Descriptor instead.
-func (*GetMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_src_proto_chat_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *GetMessagesResponse) GetMessages() []*ChatMessage {
-	if x != nil {
-		return x.Messages
-	}
-	return nil
+// Deprecated: Use SubscribeResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{86}
 }
 
-type CreateChatRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ParticipantsIds []string               `protobuf:"bytes,2,rep,name=participants_ids,json=participantsIds,proto3" json:"participants_ids,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type UnsubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateChatRequest) Reset() {
-	*x = CreateChatRequest{}
-	mi := &file_src_proto_chat_proto_msgTypes[6]
+func (x *UnsubscribeRequest) Reset() {
+	*x = UnsubscribeRequest{}
+	mi := &file_src_proto_chat_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateChatRequest) String() string {
+func (x *UnsubscribeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateChatRequest) ProtoMessage() {}
+func (*UnsubscribeRequest) ProtoMessage() {}
 
-func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_chat_proto_msgTypes[6]
+func (x *UnsubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,47 +5799,39 @@ func (x *CreateChatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateChatRequest.ProtoReflect.Descriptor instead.
-func (*CreateChatRequest) Descriptor() ([]byte, []int) {
-	return file_src_proto_chat_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UnsubscribeRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *CreateChatRequest) GetName() string {
+func (x *UnsubscribeRequest) GetChatId() string {
 	if x != nil {
-		return x.Name
+		return x.ChatId
 	}
 	return ""
 }
 
-func (x *CreateChatRequest) GetParticipantsIds() []string {
-	if x != nil {
-		return x.ParticipantsIds
-	}
-	return nil
-}
-
-type CreateChatResponse struct {
+type UnsubscribeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChatId        string                 `protobuf:"bytes,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateChatResponse) Reset() {
-	*x = CreateChatResponse{}
-	mi := &file_src_proto_chat_proto_msgTypes[7]
+func (x *UnsubscribeResponse) Reset() {
+	*x = UnsubscribeResponse{}
+	mi := &file_src_proto_chat_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateChatResponse) String() string {
+func (x *UnsubscribeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateChatResponse) ProtoMessage() {}
+func (*UnsubscribeResponse) ProtoMessage() {}
 
-func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_src_proto_chat_proto_msgTypes[7]
+func (x *UnsubscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_src_proto_chat_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -544,66 +5842,467 @@ func (x *CreateChatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateChatResponse.ProtoReflect.Descriptor instead.
-func (*CreateChatResponse) Descriptor() ([]byte, []int) {
-	return file_src_proto_chat_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *CreateChatResponse) GetChatId() string {
-	if x != nil {
-		return x.ChatId
-	}
-	return ""
+// Deprecated: Use UnsubscribeResponse.ProtoReflect.Descriptor instead.
+func (*UnsubscribeResponse) Descriptor() ([]byte, []int) {
+	return file_src_proto_chat_proto_rawDescGZIP(), []int{88}
 }
 
 var File_src_proto_chat_proto protoreflect.FileDescriptor
 
 const file_src_proto_chat_proto_rawDesc = "" +
 	"\n" +
-	"\x14src/proto/chat.proto\x12\valexchatapp\"\xc8\x02\n" +
+	"\x14src/proto/chat.proto\x12\valexchatapp\"\x8c\b\n" +
 	"\vChatMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1b\n" +
 	"\tsender_id\x18\x03 \x01(\tR\bsenderId\x12\x1c\n" +
 	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12F\n" +
 	"\x0emessage_status\x18\x05 \x01(\x0e2\x1f.alexchatapp.ChatMessage.statusR\rmessageStatus\x12\x14\n" +
-	"\x04text\x18\x06 \x01(\tH\x00R\x04text\x12\x1f\n" +
+	"\x04text\x18\x06 \x01(\tH\x00R\x04text\x12#\n" +
 	"\n" +
-	"audio_data\x18\a \x01(\fH\x00R\taudioData\x12\x1f\n" +
+	"audio_data\x18\a \x01(\fB\x02\x18\x01H\x00R\taudioData\x12#\n" +
 	"\n" +
-	"image_data\x18\b \x01(\fH\x00R\timageData\"*\n" +
+	"image_data\x18\b \x01(\fB\x02\x18\x01H\x00R\timageData\x122\n" +
+	"\x06system\x18\x11 \x01(\v2\x18.alexchatapp.SystemEventH\x00R\x06system\x124\n" +
+	"\x05image\x18\x13 \x01(\v2\x1c.alexchatapp.MediaAttachmentH\x00R\x05image\x124\n" +
+	"\x05audio\x18\x14 \x01(\v2\x1c.alexchatapp.MediaAttachmentH\x00R\x05audio\x121\n" +
+	"\x04file\x18\x15 \x01(\v2\x1b.alexchatapp.FileAttachmentH\x00R\x04file\x12\x1b\n" +
+	"\tedited_at\x18\t \x01(\x03R\beditedAt\x12\x18\n" +
+	"\adeleted\x18\n" +
+	" \x01(\bR\adeleted\x12:\n" +
+	"\treactions\x18\v \x03(\v2\x1c.alexchatapp.ReactionSummaryR\treactions\x12\x1e\n" +
+	"\vreply_to_id\x18\f \x01(\tR\treplyToId\x126\n" +
+	"\breply_to\x18\r \x01(\v2\x1b.alexchatapp.MessagePreviewR\areplyTo\x12$\n" +
+	"\x0ethread_root_id\x18\x0e \x01(\tR\fthreadRootId\x12\x1f\n" +
+	"\vreply_count\x18\x0f \x01(\x05R\n" +
+	"replyCount\x12.\n" +
+	"\x13thread_unread_count\x18\x10 \x01(\x05R\x11threadUnreadCount\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\x12 \x01(\x05R\tviewCount\x12G\n" +
+	"\x10attachment_state\x18\x16 \x01(\x0e2\x1c.alexchatapp.AttachmentStateR\x0fattachmentState\x12=\n" +
+	"\rlink_previews\x18\x17 \x03(\v2\x18.alexchatapp.LinkPreviewR\flinkPreviews\"*\n" +
 	"\x06status\x12\b\n" +
 	"\x04SENT\x10\x00\x12\f\n" +
 	"\bRECEIVED\x10\x01\x12\b\n" +
 	"\x04READ\x10\x02B\t\n" +
-	"\acontent\"*\n" +
+	"\acontent\"t\n" +
+	"\vLinkPreview\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\timage_url\x18\x04 \x01(\tR\bimageUrl\"\x85\x02\n" +
+	"\x0fMediaAttachment\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x1b\n" +
+	"\tmime_type\x18\x02 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12;\n" +
+	"\n" +
+	"thumbnails\x18\x06 \x03(\v2\x1b.alexchatapp.ImageThumbnailR\n" +
+	"thumbnails\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\x03R\n" +
+	"durationMs\x12\x1a\n" +
+	"\bwaveform\x18\b \x01(\fR\bwaveform\"x\n" +
+	"\x0eFileAttachment\x12\x19\n" +
+	"\bmedia_id\x18\x01 \x01(\tR\amediaId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\"R\n" +
+	"\x0eImageThumbnail\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x05R\x06height\"\x94\x02\n" +
+	"\vSystemEvent\x121\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1d.alexchatapp.SystemEvent.TypeR\x04type\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\x12)\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x15.alexchatapp.ChatRoleR\x04role\"\x8b\x01\n" +
+	"\x04Type\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\x11\n" +
+	"\rMEMBERS_ADDED\x10\x01\x12\x12\n" +
+	"\x0eMEMBER_REMOVED\x10\x02\x12\x0f\n" +
+	"\vMEMBER_LEFT\x10\x03\x12\x19\n" +
+	"\x15OWNERSHIP_TRANSFERRED\x10\x04\x12\x10\n" +
+	"\fROLE_CHANGED\x10\x05\x12\x11\n" +
+	"\rMEMBER_JOINED\x10\x06\"a\n" +
+	"\x0fReactionSummary\x12\x14\n" +
+	"\x05emoji\x18\x01 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\"\n" +
+	"\rreacted_by_me\x18\x03 \x01(\bR\vreactedByMe\"W\n" +
+	"\vTypingEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06typing\x18\x03 \x01(\bR\x06typing\"\xb6\x01\n" +
+	"\fReceiptEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x127\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1f.alexchatapp.ChatMessage.statusR\x06status\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"t\n" +
+	"\tEditEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x1b\n" +
+	"\tedited_at\x18\x04 \x01(\x03R\beditedAt\"h\n" +
+	"\vDeleteEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12!\n" +
+	"\ffor_everyone\x18\x03 \x01(\bR\vforEveryone\"\x90\x01\n" +
+	"\rReactionEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05emoji\x18\x04 \x01(\tR\x05emoji\x12\x18\n" +
+	"\aremoved\x18\x05 \x01(\bR\aremoved\"w\n" +
+	"\bPinEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bunpinned\x18\x04 \x01(\bR\bunpinned\"}\n" +
+	"\x0fAttachmentEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x122\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1c.alexchatapp.AttachmentStateR\x05state\"\x80\x01\n" +
+	"\x10LinkPreviewEvent\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x124\n" +
+	"\bpreviews\x18\x03 \x03(\v2\x18.alexchatapp.LinkPreviewR\bpreviews\"$\n" +
+	"\x03Ack\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\":\n" +
+	"\n" +
+	"ErrorEvent\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xfa\x02\n" +
+	"\vClientEvent\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x124\n" +
+	"\amessage\x18\x02 \x01(\v2\x18.alexchatapp.ChatMessageH\x00R\amessage\x122\n" +
+	"\x06typing\x18\x03 \x01(\v2\x18.alexchatapp.TypingEventH\x00R\x06typing\x125\n" +
+	"\areceipt\x18\x04 \x01(\v2\x19.alexchatapp.ReceiptEventH\x00R\areceipt\x12,\n" +
+	"\x04edit\x18\x05 \x01(\v2\x16.alexchatapp.EditEventH\x00R\x04edit\x122\n" +
+	"\x06delete\x18\x06 \x01(\v2\x18.alexchatapp.DeleteEventH\x00R\x06delete\x128\n" +
+	"\breaction\x18\a \x01(\v2\x1a.alexchatapp.ReactionEventH\x00R\breactionB\a\n" +
+	"\x05event\"\x80\x05\n" +
+	"\vServerEvent\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x124\n" +
+	"\amessage\x18\x02 \x01(\v2\x18.alexchatapp.ChatMessageH\x00R\amessage\x122\n" +
+	"\x06typing\x18\x03 \x01(\v2\x18.alexchatapp.TypingEventH\x00R\x06typing\x125\n" +
+	"\areceipt\x18\x04 \x01(\v2\x19.alexchatapp.ReceiptEventH\x00R\areceipt\x12,\n" +
+	"\x04edit\x18\x05 \x01(\v2\x16.alexchatapp.EditEventH\x00R\x04edit\x122\n" +
+	"\x06delete\x18\x06 \x01(\v2\x18.alexchatapp.DeleteEventH\x00R\x06delete\x128\n" +
+	"\breaction\x18\a \x01(\v2\x1a.alexchatapp.ReactionEventH\x00R\breaction\x12$\n" +
+	"\x03ack\x18\b \x01(\v2\x10.alexchatapp.AckH\x00R\x03ack\x12/\n" +
+	"\x05error\x18\t \x01(\v2\x17.alexchatapp.ErrorEventH\x00R\x05error\x12)\n" +
+	"\x03pin\x18\n" +
+	" \x01(\v2\x15.alexchatapp.PinEventH\x00R\x03pin\x12>\n" +
+	"\n" +
+	"attachment\x18\v \x01(\v2\x1c.alexchatapp.AttachmentEventH\x00R\n" +
+	"attachment\x12B\n" +
+	"\flink_preview\x18\f \x01(\v2\x1d.alexchatapp.LinkPreviewEventH\x00R\vlinkPreviewB\a\n" +
+	"\x05event\"X\n" +
 	"\x0fGetChatsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"a\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"~\n" +
+	"\x0eMessagePreview\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\"\x8f\x03\n" +
 	"\x04Chat\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
-	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01B\x0e\n" +
-	"\f_description\";\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12>\n" +
+	"\flast_message\x18\x04 \x01(\v2\x1b.alexchatapp.MessagePreviewR\vlastMessage\x12#\n" +
+	"\rlast_activity\x18\x05 \x01(\x03R\flastActivity\x12!\n" +
+	"\funread_count\x18\x06 \x01(\x05R\vunreadCount\x12#\n" +
+	"\rmention_count\x18\a \x01(\x05R\fmentionCount\x12.\n" +
+	"\x13thread_unread_count\x18\b \x01(\x05R\x11threadUnreadCount\x12)\n" +
+	"\x04type\x18\t \x01(\x0e2\x15.alexchatapp.ChatTypeR\x04type\x12$\n" +
+	"\x0edirect_user_id\x18\n" +
+	" \x01(\tR\fdirectUserIdB\x0e\n" +
+	"\f_description\"w\n" +
 	"\x10GetChatsResponse\x12'\n" +
-	"\x05chats\x18\x01 \x03(\v2\x11.alexchatapp.ChatR\x05chats\"n\n" +
+	"\x05chats\x18\x01 \x03(\v2\x11.alexchatapp.ChatR\x05chats\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"\xbe\x02\n" +
 	"\x12GetMessagesRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x14\n" +
 	"\x05count\x18\x02 \x01(\x05R\x05count\x12)\n" +
-	"\x10before_timestamp\x18\x03 \x01(\x03R\x0fbeforeTimestamp\"K\n" +
+	"\x10before_timestamp\x18\x03 \x01(\x03R\x0fbeforeTimestamp\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12G\n" +
+	"\tdirection\x18\x05 \x01(\x0e2).alexchatapp.GetMessagesRequest.DirectionR\tdirection\x12/\n" +
+	"\x11around_message_id\x18\x06 \x01(\tH\x00R\x0faroundMessageId\x88\x01\x01\"&\n" +
+	"\tDirection\x12\f\n" +
+	"\bBACKWARD\x10\x00\x12\v\n" +
+	"\aFORWARD\x10\x01B\x14\n" +
+	"\x12_around_message_id\"\xdb\x01\n" +
 	"\x13GetMessagesResponse\x124\n" +
-	"\bmessages\x18\x01 \x03(\v2\x18.alexchatapp.ChatMessageR\bmessages\"R\n" +
+	"\bmessages\x18\x01 \x03(\v2\x18.alexchatapp.ChatMessageR\bmessages\x12\x1f\n" +
+	"\vprev_cursor\x18\x02 \x01(\tR\n" +
+	"prevCursor\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12&\n" +
+	"\x0fhas_more_before\x18\x04 \x01(\bR\rhasMoreBefore\x12$\n" +
+	"\x0ehas_more_after\x18\x05 \x01(\bR\fhasMoreAfter\"\xbf\x01\n" +
+	"\x15SearchMessagesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x1b\n" +
+	"\tsender_id\x18\x03 \x01(\tR\bsenderId\x12\x14\n" +
+	"\x05after\x18\x04 \x01(\x03R\x05after\x12\x16\n" +
+	"\x06before\x18\x05 \x01(\x03R\x06before\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\"\\\n" +
+	"\fSearchResult\x122\n" +
+	"\amessage\x18\x01 \x01(\v2\x18.alexchatapp.ChatMessageR\amessage\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\"\x89\x01\n" +
+	"\x16SearchMessagesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.alexchatapp.SearchResultR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"\x9f\x01\n" +
 	"\x11CreateChatRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
-	"\x10participants_ids\x18\x02 \x03(\tR\x0fparticipantsIds\"-\n" +
+	"\x10participants_ids\x18\x02 \x03(\tR\x0fparticipantsIds\x12)\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x15.alexchatapp.ChatTypeR\x04type\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"-\n" +
 	"\x12CreateChatResponse\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\tR\x06chatId2\xbd\x02\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"D\n" +
+	"\x1cGetOrCreateDirectChatRequest\x12$\n" +
+	"\x0etarget_user_id\x18\x01 \x01(\tR\ftargetUserId\"`\n" +
+	"\x1dGetOrCreateDirectChatResponse\x12%\n" +
+	"\x04chat\x18\x01 \x01(\v2\x11.alexchatapp.ChatR\x04chat\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\bR\acreated\"X\n" +
+	"\x14MarkDeliveredRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12'\n" +
+	"\x10up_to_message_id\x18\x02 \x01(\tR\rupToMessageId\"S\n" +
+	"\x0fMarkReadRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12'\n" +
+	"\x10up_to_message_id\x18\x02 \x01(\tR\rupToMessageId\"7\n" +
+	"\x10ReceiptsResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\"e\n" +
+	"\x0eMessageReceipt\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
+	"\fdelivered_at\x18\x02 \x01(\x03R\vdeliveredAt\x12\x17\n" +
+	"\aread_at\x18\x03 \x01(\x03R\x06readAt\"S\n" +
+	"\x19GetMessageReceiptsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"U\n" +
+	"\x1aGetMessageReceiptsResponse\x127\n" +
+	"\breceipts\x18\x01 \x03(\v2\x1b.alexchatapp.MessageReceiptR\breceipts\"`\n" +
+	"\x12EditMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"I\n" +
+	"\x13EditMessageResponse\x122\n" +
+	"\amessage\x18\x01 \x01(\v2\x18.alexchatapp.ChatMessageR\amessage\"q\n" +
+	"\x14DeleteMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12!\n" +
+	"\ffor_everyone\x18\x03 \x01(\bR\vforEveryone\"\x17\n" +
+	"\x15DeleteMessageResponse\"[\n" +
+	"\vMessageEdit\x12\x1b\n" +
+	"\teditor_id\x18\x01 \x01(\tR\beditorId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1b\n" +
+	"\tedited_at\x18\x03 \x01(\x03R\beditedAt\"P\n" +
+	"\x16GetMessageEditsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"I\n" +
+	"\x17GetMessageEditsResponse\x12.\n" +
+	"\x05edits\x18\x01 \x03(\v2\x18.alexchatapp.MessageEditR\x05edits\"_\n" +
+	"\x0fReactionRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x14\n" +
+	"\x05emoji\x18\x03 \x01(\tR\x05emoji\"N\n" +
+	"\x10ReactionResponse\x12:\n" +
+	"\treactions\x18\x01 \x03(\v2\x1c.alexchatapp.ReactionSummaryR\treactions\"\x81\x01\n" +
+	"\x10GetThreadRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12&\n" +
+	"\x0froot_message_id\x18\x02 \x01(\tR\rrootMessageId\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\xd4\x01\n" +
+	"\x11GetThreadResponse\x12,\n" +
+	"\x04root\x18\x01 \x01(\v2\x18.alexchatapp.ChatMessageR\x04root\x122\n" +
+	"\areplies\x18\x02 \x03(\v2\x18.alexchatapp.ChatMessageR\areplies\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_more\x18\x04 \x01(\bR\ahasMore\x12!\n" +
+	"\funread_count\x18\x05 \x01(\x05R\vunreadCount\"\x81\x01\n" +
+	"\x15MarkThreadReadRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12&\n" +
+	"\x0froot_message_id\x18\x02 \x01(\tR\rrootMessageId\x12'\n" +
+	"\x10up_to_message_id\x18\x03 \x01(\tR\rupToMessageId\";\n" +
+	"\x16MarkThreadReadResponse\x12!\n" +
+	"\funread_count\x18\x01 \x01(\x05R\vunreadCount\"n\n" +
+	"\vParticipant\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x15.alexchatapp.ChatRoleR\x04role\x12\x1b\n" +
+	"\tjoined_at\x18\x03 \x01(\x03R\bjoinedAt\"1\n" +
+	"\x16GetParticipantsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"W\n" +
+	"\x17GetParticipantsResponse\x12<\n" +
+	"\fparticipants\x18\x01 \x03(\v2\x18.alexchatapp.ParticipantR\fparticipants\"L\n" +
+	"\x16AddParticipantsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\"?\n" +
+	"\x17AddParticipantsResponse\x12$\n" +
+	"\x0eadded_user_ids\x18\x01 \x03(\tR\faddedUserIds\"L\n" +
+	"\x18RemoveParticipantRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x1b\n" +
+	"\x19RemoveParticipantResponse\"+\n" +
+	"\x10LeaveChatRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"\x13\n" +
+	"\x11LeaveChatResponse\"U\n" +
+	"\x18TransferOwnershipRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12 \n" +
+	"\fnew_owner_id\x18\x02 \x01(\tR\n" +
+	"newOwnerId\"\x1b\n" +
+	"\x19TransferOwnershipResponse\"x\n" +
+	"\x19SetParticipantRoleRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12)\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x15.alexchatapp.ChatRoleR\x04role\"\x1c\n" +
+	"\x1aSetParticipantRoleResponse\"K\n" +
+	"\x11PinMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"\x14\n" +
+	"\x12PinMessageResponse\"M\n" +
+	"\x13UnpinMessageRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\"\x16\n" +
+	"\x14UnpinMessageResponse\"4\n" +
+	"\x19ListPinnedMessagesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"}\n" +
+	"\rPinnedMessage\x122\n" +
+	"\amessage\x18\x01 \x01(\v2\x18.alexchatapp.ChatMessageR\amessage\x12\x1b\n" +
+	"\tpinned_by\x18\x02 \x01(\tR\bpinnedBy\x12\x1b\n" +
+	"\tpinned_at\x18\x03 \x01(\x03R\bpinnedAt\"L\n" +
+	"\x1aListPinnedMessagesResponse\x12.\n" +
+	"\x04pins\x18\x01 \x03(\v2\x1a.alexchatapp.PinnedMessageR\x04pins\"\xfe\x01\n" +
+	"\x06Invite\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"creator_id\x18\x04 \x01(\tR\tcreatorId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\x12\x19\n" +
+	"\bmax_uses\x18\a \x01(\x05R\amaxUses\x12\x12\n" +
+	"\x04uses\x18\b \x01(\x05R\x04uses\x12+\n" +
+	"\x11requires_approval\x18\t \x01(\bR\x10requiresApproval\"\x95\x01\n" +
+	"\x13CreateInviteRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\x12\x19\n" +
+	"\bmax_uses\x18\x03 \x01(\x05R\amaxUses\x12+\n" +
+	"\x11requires_approval\x18\x04 \x01(\bR\x10requiresApproval\"C\n" +
+	"\x14CreateInviteResponse\x12+\n" +
+	"\x06invite\x18\x01 \x01(\v2\x13.alexchatapp.InviteR\x06invite\"K\n" +
+	"\x13RevokeInviteRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1b\n" +
+	"\tinvite_id\x18\x02 \x01(\tR\binviteId\"\x16\n" +
+	"\x14RevokeInviteResponse\"-\n" +
+	"\x12ListInvitesRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"D\n" +
+	"\x13ListInvitesResponse\x12-\n" +
+	"\ainvites\x18\x01 \x03(\v2\x13.alexchatapp.InviteR\ainvites\")\n" +
+	"\x13JoinByInviteRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"Z\n" +
+	"\x14JoinByInviteResponse\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12)\n" +
+	"\x10pending_approval\x18\x02 \x01(\bR\x0fpendingApproval\"\x8b\x01\n" +
+	"\vJoinRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\achat_id\x18\x02 \x01(\tR\x06chatId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tinvite_id\x18\x04 \x01(\tR\binviteId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"2\n" +
+	"\x17ListJoinRequestsRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"P\n" +
+	"\x18ListJoinRequestsResponse\x124\n" +
+	"\brequests\x18\x01 \x03(\v2\x18.alexchatapp.JoinRequestR\brequests\"R\n" +
+	"\x18DecideJoinRequestRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"\x1b\n" +
+	"\x19DecideJoinRequestResponse\"+\n" +
+	"\x10SubscribeRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"\x13\n" +
+	"\x11SubscribeResponse\"-\n" +
+	"\x12UnsubscribeRequest\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\tR\x06chatId\"\x15\n" +
+	"\x13UnsubscribeResponse*;\n" +
+	"\x0fAttachmentState\x12\t\n" +
+	"\x05READY\x10\x00\x12\f\n" +
+	"\bSCANNING\x10\x01\x12\x0f\n" +
+	"\vQUARANTINED\x10\x02*,\n" +
+	"\bChatRole\x12\n" +
+	"\n" +
+	"\x06MEMBER\x10\x00\x12\t\n" +
+	"\x05ADMIN\x10\x01\x12\t\n" +
+	"\x05OWNER\x10\x02*.\n" +
+	"\bChatType\x12\t\n" +
+	"\x05GROUP\x10\x00\x12\n" +
+	"\n" +
+	"\x06DIRECT\x10\x01\x12\v\n" +
+	"\aCHANNEL\x10\x022\xb0\x17\n" +
 	"\vChatService\x12D\n" +
 	"\n" +
-	"ChatStream\x12\x18.alexchatapp.ChatMessage\x1a\x18.alexchatapp.ChatMessage(\x010\x01\x12G\n" +
+	"ChatStream\x12\x18.alexchatapp.ClientEvent\x1a\x18.alexchatapp.ServerEvent(\x010\x01\x12G\n" +
 	"\bGetChats\x12\x1c.alexchatapp.GetChatsRequest\x1a\x1d.alexchatapp.GetChatsResponse\x12P\n" +
-	"\vGetMessages\x12\x1f.alexchatapp.GetMessagesRequest\x1a .alexchatapp.GetMessagesResponse\x12M\n" +
+	"\vGetMessages\x12\x1f.alexchatapp.GetMessagesRequest\x1a .alexchatapp.GetMessagesResponse\x12Y\n" +
+	"\x0eSearchMessages\x12\".alexchatapp.SearchMessagesRequest\x1a#.alexchatapp.SearchMessagesResponse\x12M\n" +
+	"\n" +
+	"CreateChat\x12\x1e.alexchatapp.CreateChatRequest\x1a\x1f.alexchatapp.CreateChatResponse\x12n\n" +
+	"\x15GetOrCreateDirectChat\x12).alexchatapp.GetOrCreateDirectChatRequest\x1a*.alexchatapp.GetOrCreateDirectChatResponse\x12Q\n" +
+	"\rMarkDelivered\x12!.alexchatapp.MarkDeliveredRequest\x1a\x1d.alexchatapp.ReceiptsResponse\x12G\n" +
+	"\bMarkRead\x12\x1c.alexchatapp.MarkReadRequest\x1a\x1d.alexchatapp.ReceiptsResponse\x12e\n" +
+	"\x12GetMessageReceipts\x12&.alexchatapp.GetMessageReceiptsRequest\x1a'.alexchatapp.GetMessageReceiptsResponse\x12P\n" +
+	"\vEditMessage\x12\x1f.alexchatapp.EditMessageRequest\x1a .alexchatapp.EditMessageResponse\x12V\n" +
+	"\rDeleteMessage\x12!.alexchatapp.DeleteMessageRequest\x1a\".alexchatapp.DeleteMessageResponse\x12\\\n" +
+	"\x0fGetMessageEdits\x12#.alexchatapp.GetMessageEditsRequest\x1a$.alexchatapp.GetMessageEditsResponse\x12J\n" +
+	"\vAddReaction\x12\x1c.alexchatapp.ReactionRequest\x1a\x1d.alexchatapp.ReactionResponse\x12M\n" +
+	"\x0eRemoveReaction\x12\x1c.alexchatapp.ReactionRequest\x1a\x1d.alexchatapp.ReactionResponse\x12J\n" +
+	"\tGetThread\x12\x1d.alexchatapp.GetThreadRequest\x1a\x1e.alexchatapp.GetThreadResponse\x12Y\n" +
+	"\x0eMarkThreadRead\x12\".alexchatapp.MarkThreadReadRequest\x1a#.alexchatapp.MarkThreadReadResponse\x12\\\n" +
+	"\x0fGetParticipants\x12#.alexchatapp.GetParticipantsRequest\x1a$.alexchatapp.GetParticipantsResponse\x12\\\n" +
+	"\x0fAddParticipants\x12#.alexchatapp.AddParticipantsRequest\x1a$.alexchatapp.AddParticipantsResponse\x12b\n" +
+	"\x11RemoveParticipant\x12%.alexchatapp.RemoveParticipantRequest\x1a&.alexchatapp.RemoveParticipantResponse\x12J\n" +
+	"\tLeaveChat\x12\x1d.alexchatapp.LeaveChatRequest\x1a\x1e.alexchatapp.LeaveChatResponse\x12b\n" +
+	"\x11TransferOwnership\x12%.alexchatapp.TransferOwnershipRequest\x1a&.alexchatapp.TransferOwnershipResponse\x12e\n" +
+	"\x12SetParticipantRole\x12&.alexchatapp.SetParticipantRoleRequest\x1a'.alexchatapp.SetParticipantRoleResponse\x12M\n" +
 	"\n" +
-	"CreateChat\x12\x1e.alexchatapp.CreateChatRequest\x1a\x1f.alexchatapp.CreateChatResponseB\x16Z\x14src/proto/chat;protob\x06proto3"
+	"PinMessage\x12\x1e.alexchatapp.PinMessageRequest\x1a\x1f.alexchatapp.PinMessageResponse\x12S\n" +
+	"\fUnpinMessage\x12 .alexchatapp.UnpinMessageRequest\x1a!.alexchatapp.UnpinMessageResponse\x12e\n" +
+	"\x12ListPinnedMessages\x12&.alexchatapp.ListPinnedMessagesRequest\x1a'.alexchatapp.ListPinnedMessagesResponse\x12S\n" +
+	"\fCreateInvite\x12 .alexchatapp.CreateInviteRequest\x1a!.alexchatapp.CreateInviteResponse\x12S\n" +
+	"\fRevokeInvite\x12 .alexchatapp.RevokeInviteRequest\x1a!.alexchatapp.RevokeInviteResponse\x12P\n" +
+	"\vListInvites\x12\x1f.alexchatapp.ListInvitesRequest\x1a .alexchatapp.ListInvitesResponse\x12S\n" +
+	"\fJoinByInvite\x12 .alexchatapp.JoinByInviteRequest\x1a!.alexchatapp.JoinByInviteResponse\x12_\n" +
+	"\x10ListJoinRequests\x12$.alexchatapp.ListJoinRequestsRequest\x1a%.alexchatapp.ListJoinRequestsResponse\x12c\n" +
+	"\x12ApproveJoinRequest\x12%.alexchatapp.DecideJoinRequestRequest\x1a&.alexchatapp.DecideJoinRequestResponse\x12b\n" +
+	"\x11RejectJoinRequest\x12%.alexchatapp.DecideJoinRequestRequest\x1a&.alexchatapp.DecideJoinRequestResponse\x12J\n" +
+	"\tSubscribe\x12\x1d.alexchatapp.SubscribeRequest\x1a\x1e.alexchatapp.SubscribeResponse\x12P\n" +
+	"\vUnsubscribe\x12\x1f.alexchatapp.UnsubscribeRequest\x1a .alexchatapp.UnsubscribeResponseB\x16Z\x14src/proto/chat;protob\x06proto3"
 
 var (
 	file_src_proto_chat_proto_rawDescOnce sync.Once
@@ -617,36 +6316,234 @@ func file_src_proto_chat_proto_rawDescGZIP() []byte {
 	return file_src_proto_chat_proto_rawDescData
 }
 
-var file_src_proto_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_src_proto_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_src_proto_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_src_proto_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 89)
 var file_src_proto_chat_proto_goTypes = []any{
-	(ChatMessageStatus)(0),      // 0: alexchatapp.ChatMessage.status
-	(*ChatMessage)(nil),         // 1: alexchatapp.ChatMessage
-	(*GetChatsRequest)(nil),     // 2: alexchatapp.GetChatsRequest
-	(*Chat)(nil),                // 3: alexchatapp.Chat
-	(*GetChatsResponse)(nil),    // 4: alexchatapp.GetChatsResponse
-	(*GetMessagesRequest)(nil),  // 5: alexchatapp.GetMessagesRequest
-	(*GetMessagesResponse)(nil), // 6: alexchatapp.GetMessagesResponse
-	(*CreateChatRequest)(nil),   // 7: alexchatapp.CreateChatRequest
-	(*CreateChatResponse)(nil),  // 8: alexchatapp.CreateChatResponse
+	(AttachmentState)(0),                  // 0: alexchatapp.AttachmentState
+	(ChatRole)(0),                         // 1: alexchatapp.ChatRole
+	(ChatType)(0),                         // 2: alexchatapp.ChatType
+	(ChatMessageStatus)(0),                // 3: alexchatapp.ChatMessage.status
+	(SystemEvent_Type)(0),                 // 4: alexchatapp.SystemEvent.Type
+	(GetMessagesRequest_Direction)(0),     // 5: alexchatapp.GetMessagesRequest.Direction
+	(*ChatMessage)(nil),                   // 6: alexchatapp.ChatMessage
+	(*LinkPreview)(nil),                   // 7: alexchatapp.LinkPreview
+	(*MediaAttachment)(nil),               // 8: alexchatapp.MediaAttachment
+	(*FileAttachment)(nil),                // 9: alexchatapp.FileAttachment
+	(*ImageThumbnail)(nil),                // 10: alexchatapp.ImageThumbnail
+	(*SystemEvent)(nil),                   // 11: alexchatapp.SystemEvent
+	(*ReactionSummary)(nil),               // 12: alexchatapp.ReactionSummary
+	(*TypingEvent)(nil),                   // 13: alexchatapp.TypingEvent
+	(*ReceiptEvent)(nil),                  // 14: alexchatapp.ReceiptEvent
+	(*EditEvent)(nil),                     // 15: alexchatapp.EditEvent
+	(*DeleteEvent)(nil),                   // 16: alexchatapp.DeleteEvent
+	(*ReactionEvent)(nil),                 // 17: alexchatapp.ReactionEvent
+	(*PinEvent)(nil),                      // 18: alexchatapp.PinEvent
+	(*AttachmentEvent)(nil),               // 19: alexchatapp.AttachmentEvent
+	(*LinkPreviewEvent)(nil),              // 20: alexchatapp.LinkPreviewEvent
+	(*Ack)(nil),                           // 21: alexchatapp.Ack
+	(*ErrorEvent)(nil),                    // 22: alexchatapp.ErrorEvent
+	(*ClientEvent)(nil),                   // 23: alexchatapp.ClientEvent
+	(*ServerEvent)(nil),                   // 24: alexchatapp.ServerEvent
+	(*GetChatsRequest)(nil),               // 25: alexchatapp.GetChatsRequest
+	(*MessagePreview)(nil),                // 26: alexchatapp.MessagePreview
+	(*Chat)(nil),                          // 27: alexchatapp.Chat
+	(*GetChatsResponse)(nil),              // 28: alexchatapp.GetChatsResponse
+	(*GetMessagesRequest)(nil),            // 29: alexchatapp.GetMessagesRequest
+	(*GetMessagesResponse)(nil),           // 30: alexchatapp.GetMessagesResponse
+	(*SearchMessagesRequest)(nil),         // 31: alexchatapp.SearchMessagesRequest
+	(*SearchResult)(nil),                  // 32: alexchatapp.SearchResult
+	(*SearchMessagesResponse)(nil),        // 33: alexchatapp.SearchMessagesResponse
+	(*CreateChatRequest)(nil),             // 34: alexchatapp.CreateChatRequest
+	(*CreateChatResponse)(nil),            // 35: alexchatapp.CreateChatResponse
+	(*GetOrCreateDirectChatRequest)(nil),  // 36: alexchatapp.GetOrCreateDirectChatRequest
+	(*GetOrCreateDirectChatResponse)(nil), // 37: alexchatapp.GetOrCreateDirectChatResponse
+	(*MarkDeliveredRequest)(nil),          // 38: alexchatapp.MarkDeliveredRequest
+	(*MarkReadRequest)(nil),               // 39: alexchatapp.MarkReadRequest
+	(*ReceiptsResponse)(nil),              // 40: alexchatapp.ReceiptsResponse
+	(*MessageReceipt)(nil),                // 41: alexchatapp.MessageReceipt
+	(*GetMessageReceiptsRequest)(nil),     // 42: alexchatapp.GetMessageReceiptsRequest
+	(*GetMessageReceiptsResponse)(nil),    // 43: alexchatapp.GetMessageReceiptsResponse
+	(*EditMessageRequest)(nil),            // 44: alexchatapp.EditMessageRequest
+	(*EditMessageResponse)(nil),           // 45: alexchatapp.EditMessageResponse
+	(*DeleteMessageRequest)(nil),          // 46: alexchatapp.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),         // 47: alexchatapp.DeleteMessageResponse
+	(*MessageEdit)(nil),                   // 48: alexchatapp.MessageEdit
+	(*GetMessageEditsRequest)(nil),        // 49: alexchatapp.GetMessageEditsRequest
+	(*GetMessageEditsResponse)(nil),       // 50: alexchatapp.GetMessageEditsResponse
+	(*ReactionRequest)(nil),               // 51: alexchatapp.ReactionRequest
+	(*ReactionResponse)(nil),              // 52: alexchatapp.ReactionResponse
+	(*GetThreadRequest)(nil),              // 53: alexchatapp.GetThreadRequest
+	(*GetThreadResponse)(nil),             // 54: alexchatapp.GetThreadResponse
+	(*MarkThreadReadRequest)(nil),         // 55: alexchatapp.MarkThreadReadRequest
+	(*MarkThreadReadResponse)(nil),        // 56: alexchatapp.MarkThreadReadResponse
+	(*Participant)(nil),                   // 57: alexchatapp.Participant
+	(*GetParticipantsRequest)(nil),        // 58: alexchatapp.GetParticipantsRequest
+	(*GetParticipantsResponse)(nil),       // 59: alexchatapp.GetParticipantsResponse
+	(*AddParticipantsRequest)(nil),        // 60: alexchatapp.AddParticipantsRequest
+	(*AddParticipantsResponse)(nil),       // 61: alexchatapp.AddParticipantsResponse
+	(*RemoveParticipantRequest)(nil),      // 62: alexchatapp.RemoveParticipantRequest
+	(*RemoveParticipantResponse)(nil),     // 63: alexchatapp.RemoveParticipantResponse
+	(*LeaveChatRequest)(nil),              // 64: alexchatapp.LeaveChatRequest
+	(*LeaveChatResponse)(nil),             // 65: alexchatapp.LeaveChatResponse
+	(*TransferOwnershipRequest)(nil),      // 66: alexchatapp.TransferOwnershipRequest
+	(*TransferOwnershipResponse)(nil),     // 67: alexchatapp.TransferOwnershipResponse
+	(*SetParticipantRoleRequest)(nil),     // 68: alexchatapp.SetParticipantRoleRequest
+	(*SetParticipantRoleResponse)(nil),    // 69: alexchatapp.SetParticipantRoleResponse
+	(*PinMessageRequest)(nil),             // 70: alexchatapp.PinMessageRequest
+	(*PinMessageResponse)(nil),            // 71: alexchatapp.PinMessageResponse
+	(*UnpinMessageRequest)(nil),           // 72: alexchatapp.UnpinMessageRequest
+	(*UnpinMessageResponse)(nil),          // 73: alexchatapp.UnpinMessageResponse
+	(*ListPinnedMessagesRequest)(nil),     // 74: alexchatapp.ListPinnedMessagesRequest
+	(*PinnedMessage)(nil),                 // 75: alexchatapp.PinnedMessage
+	(*ListPinnedMessagesResponse)(nil),    // 76: alexchatapp.ListPinnedMessagesResponse
+	(*Invite)(nil),                        // 77: alexchatapp.Invite
+	(*CreateInviteRequest)(nil),           // 78: alexchatapp.CreateInviteRequest
+	(*CreateInviteResponse)(nil),          // 79: alexchatapp.CreateInviteResponse
+	(*RevokeInviteRequest)(nil),           // 80: alexchatapp.RevokeInviteRequest
+	(*RevokeInviteResponse)(nil),          // 81: alexchatapp.RevokeInviteResponse
+	(*ListInvitesRequest)(nil),            // 82: alexchatapp.ListInvitesRequest
+	(*ListInvitesResponse)(nil),           // 83: alexchatapp.ListInvitesResponse
+	(*JoinByInviteRequest)(nil),           // 84: alexchatapp.JoinByInviteRequest
+	(*JoinByInviteResponse)(nil),          // 85: alexchatapp.JoinByInviteResponse
+	(*JoinRequest)(nil),                   // 86: alexchatapp.JoinRequest
+	(*ListJoinRequestsRequest)(nil),       // 87: alexchatapp.ListJoinRequestsRequest
+	(*ListJoinRequestsResponse)(nil),      // 88: alexchatapp.ListJoinRequestsResponse
+	(*DecideJoinRequestRequest)(nil),      // 89: alexchatapp.DecideJoinRequestRequest
+	(*DecideJoinRequestResponse)(nil),     // 90: alexchatapp.DecideJoinRequestResponse
+	(*SubscribeRequest)(nil),              // 91: alexchatapp.SubscribeRequest
+	(*SubscribeResponse)(nil),             // 92: alexchatapp.SubscribeResponse
+	(*UnsubscribeRequest)(nil),            // 93: alexchatapp.UnsubscribeRequest
+	(*UnsubscribeResponse)(nil),           // 94: alexchatapp.UnsubscribeResponse
 }
 var file_src_proto_chat_proto_depIdxs = []int32{
-	0, // 0: alexchatapp.ChatMessage.message_status:type_name -> alexchatapp.ChatMessage.status
-	3, // 1: alexchatapp.GetChatsResponse.chats:type_name -> alexchatapp.Chat
-	1, // 2: alexchatapp.GetMessagesResponse.messages:type_name -> alexchatapp.ChatMessage
-	1, // 3: alexchatapp.ChatService.ChatStream:input_type -> alexchatapp.ChatMessage
-	2, // 4: alexchatapp.ChatService.GetChats:input_type -> alexchatapp.GetChatsRequest
-	5, // 5: alexchatapp.ChatService.GetMessages:input_type -> alexchatapp.GetMessagesRequest
-	7, // 6: alexchatapp.ChatService.CreateChat:input_type -> alexchatapp.CreateChatRequest
-	1, // 7: alexchatapp.ChatService.ChatStream:output_type -> alexchatapp.ChatMessage
-	4, // 8: alexchatapp.ChatService.GetChats:output_type -> alexchatapp.GetChatsResponse
-	6, // 9: alexchatapp.ChatService.GetMessages:output_type -> alexchatapp.GetMessagesResponse
-	8, // 10: alexchatapp.ChatService.CreateChat:output_type -> alexchatapp.CreateChatResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3,  // 0: alexchatapp.ChatMessage.message_status:type_name -> alexchatapp.ChatMessage.status
+	11, // 1: alexchatapp.ChatMessage.system:type_name -> alexchatapp.SystemEvent
+	8,  // 2: alexchatapp.ChatMessage.image:type_name -> alexchatapp.MediaAttachment
+	8,  // 3: alexchatapp.ChatMessage.audio:type_name -> alexchatapp.MediaAttachment
+	9,  // 4: alexchatapp.ChatMessage.file:type_name -> alexchatapp.FileAttachment
+	12, // 5: alexchatapp.ChatMessage.reactions:type_name -> alexchatapp.ReactionSummary
+	26, // 6: alexchatapp.ChatMessage.reply_to:type_name -> alexchatapp.MessagePreview
+	0,  // 7: alexchatapp.ChatMessage.attachment_state:type_name -> alexchatapp.AttachmentState
+	7,  // 8: alexchatapp.ChatMessage.link_previews:type_name -> alexchatapp.LinkPreview
+	10, // 9: alexchatapp.MediaAttachment.thumbnails:type_name -> alexchatapp.ImageThumbnail
+	4,  // 10: alexchatapp.SystemEvent.type:type_name -> alexchatapp.SystemEvent.Type
+	1,  // 11: alexchatapp.SystemEvent.role:type_name -> alexchatapp.ChatRole
+	3,  // 12: alexchatapp.ReceiptEvent.status:type_name -> alexchatapp.ChatMessage.status
+	0,  // 13: alexchatapp.AttachmentEvent.state:type_name -> alexchatapp.AttachmentState
+	7,  // 14: alexchatapp.LinkPreviewEvent.previews:type_name -> alexchatapp.LinkPreview
+	6,  // 15: alexchatapp.ClientEvent.message:type_name -> alexchatapp.ChatMessage
+	13, // 16: alexchatapp.ClientEvent.typing:type_name -> alexchatapp.TypingEvent
+	14, // 17: alexchatapp.ClientEvent.receipt:type_name -> alexchatapp.ReceiptEvent
+	15, // 18: alexchatapp.ClientEvent.edit:type_name -> alexchatapp.EditEvent
+	16, // 19: alexchatapp.ClientEvent.delete:type_name -> alexchatapp.DeleteEvent
+	17, // 20: alexchatapp.ClientEvent.reaction:type_name -> alexchatapp.ReactionEvent
+	6,  // 21: alexchatapp.ServerEvent.message:type_name -> alexchatapp.ChatMessage
+	13, // 22: alexchatapp.ServerEvent.typing:type_name -> alexchatapp.TypingEvent
+	14, // 23: alexchatapp.ServerEvent.receipt:type_name -> alexchatapp.ReceiptEvent
+	15, // 24: alexchatapp.ServerEvent.edit:type_name -> alexchatapp.EditEvent
+	16, // 25: alexchatapp.ServerEvent.delete:type_name -> alexchatapp.DeleteEvent
+	17, // 26: alexchatapp.ServerEvent.reaction:type_name -> alexchatapp.ReactionEvent
+	21, // 27: alexchatapp.ServerEvent.ack:type_name -> alexchatapp.Ack
+	22, // 28: alexchatapp.ServerEvent.error:type_name -> alexchatapp.ErrorEvent
+	18, // 29: alexchatapp.ServerEvent.pin:type_name -> alexchatapp.PinEvent
+	19, // 30: alexchatapp.ServerEvent.attachment:type_name -> alexchatapp.AttachmentEvent
+	20, // 31: alexchatapp.ServerEvent.link_preview:type_name -> alexchatapp.LinkPreviewEvent
+	26, // 32: alexchatapp.Chat.last_message:type_name -> alexchatapp.MessagePreview
+	2,  // 33: alexchatapp.Chat.type:type_name -> alexchatapp.ChatType
+	27, // 34: alexchatapp.GetChatsResponse.chats:type_name -> alexchatapp.Chat
+	5,  // 35: alexchatapp.GetMessagesRequest.direction:type_name -> alexchatapp.GetMessagesRequest.Direction
+	6,  // 36: alexchatapp.GetMessagesResponse.messages:type_name -> alexchatapp.ChatMessage
+	6,  // 37: alexchatapp.SearchResult.message:type_name -> alexchatapp.ChatMessage
+	32, // 38: alexchatapp.SearchMessagesResponse.results:type_name -> alexchatapp.SearchResult
+	2,  // 39: alexchatapp.CreateChatRequest.type:type_name -> alexchatapp.ChatType
+	27, // 40: alexchatapp.GetOrCreateDirectChatResponse.chat:type_name -> alexchatapp.Chat
+	41, // 41: alexchatapp.GetMessageReceiptsResponse.receipts:type_name -> alexchatapp.MessageReceipt
+	6,  // 42: alexchatapp.EditMessageResponse.message:type_name -> alexchatapp.ChatMessage
+	48, // 43: alexchatapp.GetMessageEditsResponse.edits:type_name -> alexchatapp.MessageEdit
+	12, // 44: alexchatapp.ReactionResponse.reactions:type_name -> alexchatapp.ReactionSummary
+	6,  // 45: alexchatapp.GetThreadResponse.root:type_name -> alexchatapp.ChatMessage
+	6,  // 46: alexchatapp.GetThreadResponse.replies:type_name -> alexchatapp.ChatMessage
+	1,  // 47: alexchatapp.Participant.role:type_name -> alexchatapp.ChatRole
+	57, // 48: alexchatapp.GetParticipantsResponse.participants:type_name -> alexchatapp.Participant
+	1,  // 49: alexchatapp.SetParticipantRoleRequest.role:type_name -> alexchatapp.ChatRole
+	6,  // 50: alexchatapp.PinnedMessage.message:type_name -> alexchatapp.ChatMessage
+	75, // 51: alexchatapp.ListPinnedMessagesResponse.pins:type_name -> alexchatapp.PinnedMessage
+	77, // 52: alexchatapp.CreateInviteResponse.invite:type_name -> alexchatapp.Invite
+	77, // 53: alexchatapp.ListInvitesResponse.invites:type_name -> alexchatapp.Invite
+	86, // 54: alexchatapp.ListJoinRequestsResponse.requests:type_name -> alexchatapp.JoinRequest
+	23, // 55: alexchatapp.ChatService.ChatStream:input_type -> alexchatapp.ClientEvent
+	25, // 56: alexchatapp.ChatService.GetChats:input_type -> alexchatapp.GetChatsRequest
+	29, // 57: alexchatapp.ChatService.GetMessages:input_type -> alexchatapp.GetMessagesRequest
+	31, // 58: alexchatapp.ChatService.SearchMessages:input_type -> alexchatapp.SearchMessagesRequest
+	34, // 59: alexchatapp.ChatService.CreateChat:input_type -> alexchatapp.CreateChatRequest
+	36, // 60: alexchatapp.ChatService.GetOrCreateDirectChat:input_type -> alexchatapp.GetOrCreateDirectChatRequest
+	38, // 61: alexchatapp.ChatService.MarkDelivered:input_type -> alexchatapp.MarkDeliveredRequest
+	39, // 62: alexchatapp.ChatService.MarkRead:input_type -> alexchatapp.MarkReadRequest
+	42, // 63: alexchatapp.ChatService.GetMessageReceipts:input_type -> alexchatapp.GetMessageReceiptsRequest
+	44, // 64: alexchatapp.ChatService.EditMessage:input_type -> alexchatapp.EditMessageRequest
+	46, // 65: alexchatapp.ChatService.DeleteMessage:input_type -> alexchatapp.DeleteMessageRequest
+	49, // 66: alexchatapp.ChatService.GetMessageEdits:input_type -> alexchatapp.GetMessageEditsRequest
+	51, // 67: alexchatapp.ChatService.AddReaction:input_type -> alexchatapp.ReactionRequest
+	51, // 68: alexchatapp.ChatService.RemoveReaction:input_type -> alexchatapp.ReactionRequest
+	53, // 69: alexchatapp.ChatService.GetThread:input_type -> alexchatapp.GetThreadRequest
+	55, // 70: alexchatapp.ChatService.MarkThreadRead:input_type -> alexchatapp.MarkThreadReadRequest
+	58, // 71: alexchatapp.ChatService.GetParticipants:input_type -> alexchatapp.GetParticipantsRequest
+	60, // 72: alexchatapp.ChatService.AddParticipants:input_type -> alexchatapp.AddParticipantsRequest
+	62, // 73: alexchatapp.ChatService.RemoveParticipant:input_type -> alexchatapp.RemoveParticipantRequest
+	64, // 74: alexchatapp.ChatService.LeaveChat:input_type -> alexchatapp.LeaveChatRequest
+	66, // 75: alexchatapp.ChatService.TransferOwnership:input_type -> alexchatapp.TransferOwnershipRequest
+	68, // 76: alexchatapp.ChatService.SetParticipantRole:input_type -> alexchatapp.SetParticipantRoleRequest
+	70, // 77: alexchatapp.ChatService.PinMessage:input_type -> alexchatapp.PinMessageRequest
+	72, // 78: alexchatapp.ChatService.UnpinMessage:input_type -> alexchatapp.UnpinMessageRequest
+	74, // 79: alexchatapp.ChatService.ListPinnedMessages:input_type -> alexchatapp.ListPinnedMessagesRequest
+	78, // 80: alexchatapp.ChatService.CreateInvite:input_type -> alexchatapp.CreateInviteRequest
+	80, // 81: alexchatapp.ChatService.RevokeInvite:input_type -> alexchatapp.RevokeInviteRequest
+	82, // 82: alexchatapp.ChatService.ListInvites:input_type -> alexchatapp.ListInvitesRequest
+	84, // 83: alexchatapp.ChatService.JoinByInvite:input_type -> alexchatapp.JoinByInviteRequest
+	87, // 84: alexchatapp.ChatService.ListJoinRequests:input_type -> alexchatapp.ListJoinRequestsRequest
+	89, // 85: alexchatapp.ChatService.ApproveJoinRequest:input_type -> alexchatapp.DecideJoinRequestRequest
+	89, // 86: alexchatapp.ChatService.RejectJoinRequest:input_type -> alexchatapp.DecideJoinRequestRequest
+	91, // 87: alexchatapp.ChatService.Subscribe:input_type -> alexchatapp.SubscribeRequest
+	93, // 88: alexchatapp.ChatService.Unsubscribe:input_type -> alexchatapp.UnsubscribeRequest
+	24, // 89: alexchatapp.ChatService.ChatStream:output_type -> alexchatapp.ServerEvent
+	28, // 90: alexchatapp.ChatService.GetChats:output_type -> alexchatapp.GetChatsResponse
+	30, // 91: alexchatapp.ChatService.GetMessages:output_type -> alexchatapp.GetMessagesResponse
+	33, // 92: alexchatapp.ChatService.SearchMessages:output_type -> alexchatapp.SearchMessagesResponse
+	35, // 93: alexchatapp.ChatService.CreateChat:output_type -> alexchatapp.CreateChatResponse
+	37, // 94: alexchatapp.ChatService.GetOrCreateDirectChat:output_type -> alexchatapp.GetOrCreateDirectChatResponse
+	40, // 95: alexchatapp.ChatService.MarkDelivered:output_type -> alexchatapp.ReceiptsResponse
+	40, // 96: alexchatapp.ChatService.MarkRead:output_type -> alexchatapp.ReceiptsResponse
+	43, // 97: alexchatapp.ChatService.GetMessageReceipts:output_type -> alexchatapp.GetMessageReceiptsResponse
+	45, // 98: alexchatapp.ChatService.EditMessage:output_type -> alexchatapp.EditMessageResponse
+	47, // 99: alexchatapp.ChatService.DeleteMessage:output_type -> alexchatapp.DeleteMessageResponse
+	50, // 100: alexchatapp.ChatService.GetMessageEdits:output_type -> alexchatapp.GetMessageEditsResponse
+	52, // 101: alexchatapp.ChatService.AddReaction:output_type -> alexchatapp.ReactionResponse
+	52, // 102: alexchatapp.ChatService.RemoveReaction:output_type -> alexchatapp.ReactionResponse
+	54, // 103: alexchatapp.ChatService.GetThread:output_type -> alexchatapp.GetThreadResponse
+	56, // 104: alexchatapp.ChatService.MarkThreadRead:output_type -> alexchatapp.MarkThreadReadResponse
+	59, // 105: alexchatapp.ChatService.GetParticipants:output_type -> alexchatapp.GetParticipantsResponse
+	61, // 106: alexchatapp.ChatService.AddParticipants:output_type -> alexchatapp.AddParticipantsResponse
+	63, // 107: alexchatapp.ChatService.RemoveParticipant:output_type -> alexchatapp.RemoveParticipantResponse
+	65, // 108: alexchatapp.ChatService.LeaveChat:output_type -> alexchatapp.LeaveChatResponse
+	67, // 109: alexchatapp.ChatService.TransferOwnership:output_type -> alexchatapp.TransferOwnershipResponse
+	69, // 110: alexchatapp.ChatService.SetParticipantRole:output_type -> alexchatapp.SetParticipantRoleResponse
+	71, // 111: alexchatapp.ChatService.PinMessage:output_type -> alexchatapp.PinMessageResponse
+	73, // 112: alexchatapp.ChatService.UnpinMessage:output_type -> alexchatapp.UnpinMessageResponse
+	76, // 113: alexchatapp.ChatService.ListPinnedMessages:output_type -> alexchatapp.ListPinnedMessagesResponse
+	79, // 114: alexchatapp.ChatService.CreateInvite:output_type -> alexchatapp.CreateInviteResponse
+	81, // 115: alexchatapp.ChatService.RevokeInvite:output_type -> alexchatapp.RevokeInviteResponse
+	83, // 116: alexchatapp.ChatService.ListInvites:output_type -> alexchatapp.ListInvitesResponse
+	85, // 117: alexchatapp.ChatService.JoinByInvite:output_type -> alexchatapp.JoinByInviteResponse
+	88, // 118: alexchatapp.ChatService.ListJoinRequests:output_type -> alexchatapp.ListJoinRequestsResponse
+	90, // 119: alexchatapp.ChatService.ApproveJoinRequest:output_type -> alexchatapp.DecideJoinRequestResponse
+	90, // 120: alexchatapp.ChatService.RejectJoinRequest:output_type -> alexchatapp.DecideJoinRequestResponse
+	92, // 121: alexchatapp.ChatService.Subscribe:output_type -> alexchatapp.SubscribeResponse
+	94, // 122: alexchatapp.ChatService.Unsubscribe:output_type -> alexchatapp.UnsubscribeResponse
+	89, // [89:123] is the sub-list for method output_type
+	55, // [55:89] is the sub-list for method input_type
+	55, // [55:55] is the sub-list for extension type_name
+	55, // [55:55] is the sub-list for extension extendee
+	0,  // [0:55] is the sub-list for field type_name
 }
 
 func init() { file_src_proto_chat_proto_init() }
@@ -658,15 +6555,41 @@ func file_src_proto_chat_proto_init() {
 		(*ChatMessage_Text)(nil),
 		(*ChatMessage_AudioData)(nil),
 		(*ChatMessage_ImageData)(nil),
+		(*ChatMessage_System)(nil),
+		(*ChatMessage_Image)(nil),
+		(*ChatMessage_Audio)(nil),
+		(*ChatMessage_File)(nil),
+	}
+	file_src_proto_chat_proto_msgTypes[17].OneofWrappers = []any{
+		(*ClientEvent_Message)(nil),
+		(*ClientEvent_Typing)(nil),
+		(*ClientEvent_Receipt)(nil),
+		(*ClientEvent_Edit)(nil),
+		(*ClientEvent_Delete)(nil),
+		(*ClientEvent_Reaction)(nil),
+	}
+	file_src_proto_chat_proto_msgTypes[18].OneofWrappers = []any{
+		(*ServerEvent_Message)(nil),
+		(*ServerEvent_Typing)(nil),
+		(*ServerEvent_Receipt)(nil),
+		(*ServerEvent_Edit)(nil),
+		(*ServerEvent_Delete)(nil),
+		(*ServerEvent_Reaction)(nil),
+		(*ServerEvent_Ack)(nil),
+		(*ServerEvent_Error)(nil),
+		(*ServerEvent_Pin)(nil),
+		(*ServerEvent_Attachment)(nil),
+		(*ServerEvent_LinkPreview)(nil),
 	}
-	file_src_proto_chat_proto_msgTypes[2].OneofWrappers = []any{}
+	file_src_proto_chat_proto_msgTypes[21].OneofWrappers = []any{}
+	file_src_proto_chat_proto_msgTypes[23].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_src_proto_chat_proto_rawDesc), len(file_src_proto_chat_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      6,
+			NumMessages:   89,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
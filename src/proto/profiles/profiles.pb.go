@@ -23,13 +23,15 @@ const (
 )
 
 type Profile struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ProfileName   string                 `protobuf:"bytes,2,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
-	Bio           *string                `protobuf:"bytes,3,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
-	AvatarUrl     *string                `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
-	Status        *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
-	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // Ignore
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	UserId      uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProfileName string                 `protobuf:"bytes,2,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
+	Bio         *string                `protobuf:"bytes,3,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	AvatarUrl   *string                `protobuf:"bytes,4,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
+	Status      *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	LastSeen    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // Ignore
+	// Image uploaded with MediaService, visible to every user
+	AvatarMediaId *string `protobuf:"bytes,7,opt,name=avatar_media_id,json=avatarMediaId,proto3,oneof" json:"avatar_media_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,12 +108,20 @@ func (x *Profile) GetLastSeen() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Profile) GetAvatarMediaId() string {
+	if x != nil && x.AvatarMediaId != nil {
+		return *x.AvatarMediaId
+	}
+	return ""
+}
+
 type CreateProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProfileName   string                 `protobuf:"bytes,1,opt,name=profile_name,json=profileName,proto3" json:"profile_name,omitempty"`
 	Bio           *string                `protobuf:"bytes,2,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
 	AvatarUrl     *string                `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
 	Status        *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	AvatarMediaId *string                `protobuf:"bytes,5,opt,name=avatar_media_id,json=avatarMediaId,proto3,oneof" json:"avatar_media_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -174,6 +184,13 @@ func (x *CreateProfileRequest) GetStatus() string {
 	return ""
 }
 
+func (x *CreateProfileRequest) GetAvatarMediaId() string {
+	if x != nil && x.AvatarMediaId != nil {
+		return *x.AvatarMediaId
+	}
+	return ""
+}
+
 type CreateProfileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StatusCode    int64                  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
@@ -307,11 +324,13 @@ func (x *GetProfileResponse) GetProfile() *Profile {
 }
 
 type UpdateProfileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProfileName   *string                `protobuf:"bytes,1,opt,name=profile_name,json=profileName,proto3,oneof" json:"profile_name,omitempty"`
-	Bio           *string                `protobuf:"bytes,2,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
-	AvatarUrl     *string                `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
-	Status        *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProfileName *string                `protobuf:"bytes,1,opt,name=profile_name,json=profileName,proto3,oneof" json:"profile_name,omitempty"`
+	Bio         *string                `protobuf:"bytes,2,opt,name=bio,proto3,oneof" json:"bio,omitempty"`
+	AvatarUrl   *string                `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
+	Status      *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	// Keeps the current avatar when unset, an empty value removes it
+	AvatarMediaId *string `protobuf:"bytes,5,opt,name=avatar_media_id,json=avatarMediaId,proto3,oneof" json:"avatar_media_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -374,6 +393,13 @@ func (x *UpdateProfileRequest) GetStatus() string {
 	return ""
 }
 
+func (x *UpdateProfileRequest) GetAvatarMediaId() string {
+	if x != nil && x.AvatarMediaId != nil {
+		return *x.AvatarMediaId
+	}
+	return ""
+}
+
 type UpdateProfileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StatusCode    int64                  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
@@ -518,7 +544,7 @@ var File_src_proto_profiles_proto protoreflect.FileDescriptor
 
 const file_src_proto_profiles_proto_rawDesc = "" +
 	"\n" +
-	"\x18src/proto/profiles.proto\x12\valexchatapp\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf8\x01\n" +
+	"\x18src/proto/profiles.proto\x12\valexchatapp\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb9\x02\n" +
 	"\aProfile\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12!\n" +
 	"\fprofile_name\x18\x02 \x01(\tR\vprofileName\x12\x15\n" +
@@ -526,19 +552,23 @@ const file_src_proto_profiles_proto_rawDesc = "" +
 	"\n" +
 	"avatar_url\x18\x04 \x01(\tH\x01R\tavatarUrl\x88\x01\x01\x12\x1b\n" +
 	"\x06status\x18\x05 \x01(\tH\x02R\x06status\x88\x01\x01\x127\n" +
-	"\tlast_seen\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeenB\x06\n" +
+	"\tlast_seen\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12+\n" +
+	"\x0favatar_media_id\x18\a \x01(\tH\x03R\ravatarMediaId\x88\x01\x01B\x06\n" +
 	"\x04_bioB\r\n" +
 	"\v_avatar_urlB\t\n" +
-	"\a_status\"\xb3\x01\n" +
+	"\a_statusB\x12\n" +
+	"\x10_avatar_media_id\"\xf4\x01\n" +
 	"\x14CreateProfileRequest\x12!\n" +
 	"\fprofile_name\x18\x01 \x01(\tR\vprofileName\x12\x15\n" +
 	"\x03bio\x18\x02 \x01(\tH\x00R\x03bio\x88\x01\x01\x12\"\n" +
 	"\n" +
 	"avatar_url\x18\x03 \x01(\tH\x01R\tavatarUrl\x88\x01\x01\x12\x1b\n" +
-	"\x06status\x18\x04 \x01(\tH\x02R\x06status\x88\x01\x01B\x06\n" +
+	"\x06status\x18\x04 \x01(\tH\x02R\x06status\x88\x01\x01\x12+\n" +
+	"\x0favatar_media_id\x18\x05 \x01(\tH\x03R\ravatarMediaId\x88\x01\x01B\x06\n" +
 	"\x04_bioB\r\n" +
 	"\v_avatar_urlB\t\n" +
-	"\a_status\"8\n" +
+	"\a_statusB\x12\n" +
+	"\x10_avatar_media_id\"8\n" +
 	"\x15CreateProfileResponse\x12\x1f\n" +
 	"\vstatus_code\x18\x01 \x01(\x03R\n" +
 	"statusCode\"Q\n" +
@@ -546,17 +576,19 @@ const file_src_proto_profiles_proto_rawDesc = "" +
 	"\x0etarget_user_id\x18\x01 \x01(\x04H\x00R\ftargetUserId\x88\x01\x01B\x11\n" +
 	"\x0f_target_user_id\"D\n" +
 	"\x12GetProfileResponse\x12.\n" +
-	"\aprofile\x18\x01 \x01(\v2\x14.alexchatapp.ProfileR\aprofile\"\xc9\x01\n" +
+	"\aprofile\x18\x01 \x01(\v2\x14.alexchatapp.ProfileR\aprofile\"\x8a\x02\n" +
 	"\x14UpdateProfileRequest\x12&\n" +
 	"\fprofile_name\x18\x01 \x01(\tH\x00R\vprofileName\x88\x01\x01\x12\x15\n" +
 	"\x03bio\x18\x02 \x01(\tH\x01R\x03bio\x88\x01\x01\x12\"\n" +
 	"\n" +
 	"avatar_url\x18\x03 \x01(\tH\x02R\tavatarUrl\x88\x01\x01\x12\x1b\n" +
-	"\x06status\x18\x04 \x01(\tH\x03R\x06status\x88\x01\x01B\x0f\n" +
+	"\x06status\x18\x04 \x01(\tH\x03R\x06status\x88\x01\x01\x12+\n" +
+	"\x0favatar_media_id\x18\x05 \x01(\tH\x04R\ravatarMediaId\x88\x01\x01B\x0f\n" +
 	"\r_profile_nameB\x06\n" +
 	"\x04_bioB\r\n" +
 	"\v_avatar_urlB\t\n" +
-	"\a_status\"f\n" +
+	"\a_statusB\x12\n" +
+	"\x10_avatar_media_id\"f\n" +
 	"\x15UpdateProfileResponse\x12\x1f\n" +
 	"\vstatus_code\x18\x01 \x01(\x03R\n" +
 	"statusCode\x12\x1f\n" +
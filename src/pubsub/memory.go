@@ -0,0 +1,48 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBroker delivers events within the process.
+// Several Run calls on one broker behave like several nodes, which makes it handy for tests.
+type MemoryBroker struct {
+	mu       sync.RWMutex
+	handlers map[int]Handler
+	nextID   int
+}
+
+// NewMemoryBroker creates a new in-process broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		handlers: make(map[int]Handler),
+	}
+}
+
+// Publish passes the event to every running handler
+func (b *MemoryBroker) Publish(ctx context.Context, event *Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+	return nil
+}
+
+// Run registers the handler until the context is cancelled
+func (b *MemoryBroker) Run(ctx context.Context, handler Handler) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,176 @@
+package pubsub
+
+import (
+	"alexchatapp/src/hub"
+	pb "alexchatapp/src/proto/chat"
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// startNodes runs count nodes on the broker until the test ends, every node is a hub fed by Dispatch
+// the way ChatServer.DeliverEvent feeds it
+func startNodes(t *testing.T, broker *MemoryBroker, count int) []*hub.Hub {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	nodes := make([]*hub.Hub, count)
+	for i := range nodes {
+		node := hub.NewHub(hub.DefaultBufferSize, hub.PolicyDrop)
+		nodes[i] = node
+		go broker.Run(ctx, func(event *Event) { Dispatch(node, event) })
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		broker.mu.RLock()
+		running := len(broker.handlers)
+		broker.mu.RUnlock()
+		if running == count {
+			return nodes
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d of %d nodes running", running, count)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func receive(t *testing.T, subscriber *hub.Subscriber) *pb.ServerEvent {
+	t.Helper()
+	select {
+	case event := <-subscriber.Events():
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return nil
+	}
+}
+
+func expectNothing(t *testing.T, subscriber *hub.Subscriber) {
+	t.Helper()
+	select {
+	case event := <-subscriber.Events():
+		t.Fatalf("unexpected event %v", event)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func typingEvent(chat_id string) *pb.ServerEvent {
+	return &pb.ServerEvent{Event: &pb.ServerEvent_Typing{Typing: &pb.TypingEvent{ChatId: chat_id, Typing: true}}}
+}
+
+func TestMemoryBrokerFansOutAcrossNodes(t *testing.T) {
+	broker := NewMemoryBroker()
+	nodes := startNodes(t, broker, 2)
+
+	// User 1 is connected to the first node twice, user 2 to the second node, user 3 is offline
+	first := nodes[0].Subscribe(1)
+	second := nodes[0].Subscribe(1)
+	other := nodes[1].Subscribe(2)
+
+	event := typingEvent("7")
+	if err := broker.Publish(context.Background(), &Event{UserIDs: []uint{1, 2, 3}, Payload: event}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, subscriber := range []*hub.Subscriber{first, second, other} {
+		if got := receive(t, subscriber); !proto.Equal(got, event) {
+			t.Fatalf("got %v, want %v", got, event)
+		}
+	}
+}
+
+func TestMemoryBrokerDeliversOnlyToRecipients(t *testing.T) {
+	broker := NewMemoryBroker()
+	nodes := startNodes(t, broker, 2)
+
+	recipient := nodes[1].Subscribe(1)
+	bystander := nodes[0].Subscribe(2)
+
+	if err := broker.Publish(context.Background(), &Event{UserIDs: []uint{1}, Payload: typingEvent("7")}); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, recipient)
+	expectNothing(t, bystander)
+}
+
+func TestMemoryBrokerChannelFollowers(t *testing.T) {
+	broker := NewMemoryBroker()
+	nodes := startNodes(t, broker, 2)
+
+	follower := nodes[0].Subscribe(1)
+	remoteFollower := nodes[1].Subscribe(2)
+	stranger := nodes[1].Subscribe(3)
+
+	follow := true
+	err := broker.Publish(context.Background(), &Event{UserIDs: []uint{1, 2}, ChannelID: 9, Follow: &follow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := broker.Publish(context.Background(), &Event{ChannelID: 9, Payload: typingEvent("9")}); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, follower)
+	receive(t, remoteFollower)
+	expectNothing(t, stranger)
+
+	unfollow := false
+	err = broker.Publish(context.Background(), &Event{UserIDs: []uint{2}, ChannelID: 9, Follow: &unfollow})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := broker.Publish(context.Background(), &Event{ChannelID: 9, Payload: typingEvent("9")}); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, follower)
+	expectNothing(t, remoteFollower)
+}
+
+func TestMemoryBrokerStopsDeliveringToStoppedNodes(t *testing.T) {
+	broker := NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		broker.Run(ctx, func(event *Event) { t.Error("stopped node received an event") })
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	if err := broker.Publish(context.Background(), &Event{UserIDs: []uint{1}, Payload: typingEvent("7")}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEventEncoding(t *testing.T) {
+	follow := true
+	events := []*Event{
+		{UserIDs: []uint{1, 2}, Payload: typingEvent("7")},
+		{ChannelID: 9, Payload: typingEvent("9")},
+		{UserIDs: []uint{3}, ChannelID: 9, Follow: &follow},
+	}
+
+	for _, event := range events {
+		data, err := encodeEvent(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := decodeEvent(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(decoded.UserIDs) != len(event.UserIDs) || decoded.ChannelID != event.ChannelID {
+			t.Fatalf("decoded %+v, want %+v", decoded, event)
+		}
+		if (decoded.Follow == nil) != (event.Follow == nil) || (event.Follow != nil && *decoded.Follow != *event.Follow) {
+			t.Fatalf("decoded follow %v, want %v", decoded.Follow, event.Follow)
+		}
+		if event.Payload != nil && !proto.Equal(decoded.Payload, event.Payload) {
+			t.Fatalf("decoded payload %v, want %v", decoded.Payload, event.Payload)
+		}
+	}
+}
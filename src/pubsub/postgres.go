@@ -0,0 +1,155 @@
+package pubsub
+
+import (
+	"alexchatapp/src/data"
+	"alexchatapp/src/utils"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// DefaultChannel is the Postgres channel chat events are sent to
+	DefaultChannel = "chat_events"
+
+	// Postgres limits NOTIFY payloads to 8000 bytes, larger events are stored in a table
+	maxNotifyPayload = 7000
+	payloadRefPrefix = "ref:"
+	payloadTTL       = 5 * time.Minute
+
+	reconnectDelay    = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// PostgresBroker distributes events between nodes with Postgres LISTEN/NOTIFY
+type PostgresBroker struct {
+	dsn         string
+	channel     string
+	events_repo *data.ChatEventsRepository
+}
+
+// NewPostgresBroker creates a broker listening on its own connection opened with dsn
+func NewPostgresBroker(dsn string, events_repo *data.ChatEventsRepository) *PostgresBroker {
+	return &PostgresBroker{
+		dsn:         dsn,
+		channel:     DefaultChannel,
+		events_repo: events_repo,
+	}
+}
+
+// Publish sends the event with NOTIFY
+func (b *PostgresBroker) Publish(ctx context.Context, event *Event) error {
+	encoded, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	payload := string(encoded)
+	if len(encoded) > maxNotifyPayload {
+		id, err := b.events_repo.SavePayload(encoded)
+		if err != nil {
+			return err
+		}
+		payload = payloadRefPrefix + utils.FormatID(id)
+	}
+
+	return b.events_repo.Notify(b.channel, payload)
+}
+
+// Run listens for notifications and reconnects when the connection is lost
+func (b *PostgresBroker) Run(ctx context.Context, handler Handler) error {
+	go b.cleanupPayloads(ctx)
+
+	delay := reconnectDelay
+	for {
+		started := time.Now()
+		err := b.listen(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		// A connection that lived for a while is not a reason to keep backing off
+		if time.Since(started) > maxReconnectDelay {
+			delay = reconnectDelay
+		}
+		log.Printf("Chat events listener error: %v, reconnecting in %s", err, delay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// listen holds a dedicated connection and handles notifications until an error occurs
+func (b *PostgresBroker) listen(ctx context.Context, handler Handler) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return err
+	}
+	log.Printf("Listening for chat events on channel %s", b.channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		event, err := b.resolvePayload(notification.Payload)
+		if err != nil {
+			log.Printf("Skipping chat event: %v", err)
+			continue
+		}
+		handler(event)
+	}
+}
+
+// resolvePayload decodes an inline payload or loads a stored one
+func (b *PostgresBroker) resolvePayload(payload string) (*Event, error) {
+	if !strings.HasPrefix(payload, payloadRefPrefix) {
+		return decodeEvent([]byte(payload))
+	}
+
+	id, err := utils.ParseID(strings.TrimPrefix(payload, payloadRefPrefix))
+	if err != nil {
+		return nil, errors.New("invalid payload reference: " + payload)
+	}
+
+	stored, err := b.events_repo.GetPayload(id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeEvent(stored)
+}
+
+// cleanupPayloads periodically removes stored payloads every node had time to read
+func (b *PostgresBroker) cleanupPayloads(ctx context.Context) {
+	ticker := time.NewTicker(payloadTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.events_repo.DeletePayloadsBefore(time.Now().Add(-payloadTTL)); err != nil {
+				log.Printf("Chat events cleanup error: %v", err)
+			}
+		}
+	}
+}
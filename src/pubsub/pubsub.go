@@ -0,0 +1,86 @@
+package pubsub
+
+import (
+	"alexchatapp/src/hub"
+	pb "alexchatapp/src/proto/chat"
+	"context"
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Event is a stream event that has to reach the streams of the given users on every node
+type Event struct {
+	UserIDs []uint
+	// ChannelID sends the payload to every stream following the channel instead of UserIDs
+	ChannelID uint
+	Payload   *pb.ServerEvent
+	// Follow, if set, makes the streams of UserIDs start (true) or stop (false) following ChannelID,
+	// such events have no payload
+	Follow *bool
+}
+
+// Handler is called for every event received by the node
+type Handler func(event *Event)
+
+// Broker distributes chat events between server nodes
+type Broker interface {
+	// Publish sends the event to every node, including the current one
+	Publish(ctx context.Context, event *Event) error
+	// Run delivers received events to the handler until the context is cancelled
+	Run(ctx context.Context, handler Handler) error
+}
+
+// Dispatch passes an event received by the node to the streams of its hub
+func Dispatch(chat_hub *hub.Hub, event *Event) {
+	switch {
+	case event.Follow != nil:
+		for _, user_id := range event.UserIDs {
+			if *event.Follow {
+				chat_hub.Follow(user_id, event.ChannelID)
+			} else {
+				chat_hub.Unfollow(user_id, event.ChannelID)
+			}
+		}
+	case event.ChannelID != 0:
+		chat_hub.PublishChannel(event.ChannelID, event.Payload)
+	default:
+		chat_hub.Publish(event.UserIDs, event.Payload)
+	}
+}
+
+// wireEvent is the serialized form of Event
+type wireEvent struct {
+	UserIDs   []uint `json:"user_ids,omitempty"`
+	ChannelID uint   `json:"channel_id,omitempty"`
+	Payload   []byte `json:"payload,omitempty"`
+	Follow    *bool  `json:"follow,omitempty"`
+}
+
+func encodeEvent(event *Event) ([]byte, error) {
+	wire := wireEvent{UserIDs: event.UserIDs, ChannelID: event.ChannelID, Follow: event.Follow}
+	if event.Payload != nil {
+		payload, err := proto.Marshal(event.Payload)
+		if err != nil {
+			return nil, err
+		}
+		wire.Payload = payload
+	}
+	return json.Marshal(wire)
+}
+
+func decodeEvent(data []byte) (*Event, error) {
+	var wire wireEvent
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, err
+	}
+
+	event := &Event{UserIDs: wire.UserIDs, ChannelID: wire.ChannelID, Follow: wire.Follow}
+	if wire.Follow == nil {
+		event.Payload = &pb.ServerEvent{}
+		if err := proto.Unmarshal(wire.Payload, event.Payload); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
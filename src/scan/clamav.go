@@ -0,0 +1,117 @@
+package scan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamChunkSize is the size of the chunks streamed to clamd
+const clamChunkSize = 64 << 10
+
+// ClamAV scans content with a clamd daemon, or anything speaking its protocol, using INSTREAM over TCP.
+// clamd rejects streams longer than its StreamMaxLength setting, scans of such content fail.
+type ClamAV struct {
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV creates a scanner connecting to the address for every scan, 0 means no timeout
+func NewClamAV(address string, timeout time.Duration) *ClamAV {
+	return &ClamAV{address: address, timeout: timeout}
+}
+
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	// Reads and writes block on the connection, the deadline is the only way to interrupt them
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if err := sendStream(conn, r); err != nil {
+		// clamd replies and closes the connection when the stream exceeds its size limit
+		if reply, replyErr := readReply(conn); replyErr == nil {
+			return parseReply(reply)
+		}
+		return Result{}, err
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return Result{}, err
+	}
+	return parseReply(reply)
+}
+
+// sendStream sends the INSTREAM command followed by the content in length-prefixed chunks
+// and the zero length chunk ending it
+func sendStream(conn net.Conn, r io.Reader) error {
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+
+	buffer := make([]byte, 4+clamChunkSize)
+	for {
+		n, err := io.ReadFull(r, buffer[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buffer, uint32(n))
+			if _, err := conn.Write(buffer[:4+n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := conn.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// readReply reads the reply to a z-prefixed command, which ends with a null byte
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(err == io.EOF && reply != "") {
+		return "", err
+	}
+	return strings.TrimRight(reply, "\x00\n"), nil
+}
+
+// parseReply reads "stream: OK", "stream: <signature> FOUND" or "<message> ERROR"
+func parseReply(reply string) (Result, error) {
+	if strings.HasSuffix(reply, " ERROR") {
+		return Result{}, fmt.Errorf("clamav: %s", reply)
+	}
+
+	_, verdict, ok := strings.Cut(reply, ": ")
+	switch {
+	case !ok:
+	case verdict == "OK":
+		return Result{Clean: true}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return Result{Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	}
+	return Result{}, fmt.Errorf("clamav: unexpected reply %q", reply)
+}
@@ -0,0 +1,207 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd speaks the INSTREAM protocol of clamd on a local port
+type fakeClamd struct {
+	listener net.Listener
+	// reply returns the reply to the streamed content
+	reply func(content []byte) string
+	// maxSize makes the daemon stop reading and reply with a size limit error once the stream is longer
+	maxSize int
+	// stall keeps the connection open without replying
+	stall bool
+	// received gets the content of every complete stream and the sizes of its chunks
+	received chan stream
+}
+
+type stream struct {
+	content []byte
+	chunks  []int
+}
+
+func startClamd(t *testing.T, clamd *fakeClamd) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	clamd.listener = listener
+	clamd.received = make(chan stream, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go clamd.serve(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func (c *fakeClamd) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	command, err := r.ReadString(0)
+	if err != nil || command != "zINSTREAM\x00" {
+		io.WriteString(conn, "UNKNOWN COMMAND\x00")
+		return
+	}
+	if c.stall {
+		io.Copy(io.Discard, r)
+		return
+	}
+
+	var received stream
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		chunk := make([]byte, size)
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return
+		}
+		received.content = append(received.content, chunk...)
+		received.chunks = append(received.chunks, int(size))
+
+		if c.maxSize > 0 && len(received.content) > c.maxSize {
+			io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+			return
+		}
+	}
+
+	c.received <- received
+	io.WriteString(conn, c.reply(received.content)+"\x00")
+}
+
+func clean([]byte) string { return "stream: OK" }
+
+func TestClamAVStreamsContentInChunks(t *testing.T) {
+	clamd := &fakeClamd{reply: clean}
+	scanner := NewClamAV(startClamd(t, clamd), time.Second)
+
+	content := bytes.Repeat([]byte("0123456789abcdef"), (2*clamChunkSize+100)/16)
+	result, err := scanner.Scan(context.Background(), bytes.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Clean {
+		t.Fatalf("result %+v, want clean", result)
+	}
+
+	received := <-clamd.received
+	if !bytes.Equal(received.content, content) {
+		t.Fatalf("clamd received %d bytes, want the %d bytes of the content", len(received.content), len(content))
+	}
+	want := []int{clamChunkSize, clamChunkSize, len(content) - 2*clamChunkSize}
+	if len(received.chunks) != len(want) {
+		t.Fatalf("chunks %v, want %v", received.chunks, want)
+	}
+	for i := range want {
+		if received.chunks[i] != want[i] {
+			t.Fatalf("chunks %v, want %v", received.chunks, want)
+		}
+	}
+}
+
+func TestClamAVEmptyContent(t *testing.T) {
+	clamd := &fakeClamd{reply: clean}
+	scanner := NewClamAV(startClamd(t, clamd), time.Second)
+
+	if _, err := scanner.Scan(context.Background(), strings.NewReader("")); err != nil {
+		t.Fatal(err)
+	}
+	if received := <-clamd.received; len(received.chunks) != 0 {
+		t.Fatalf("empty content was sent in chunks %v", received.chunks)
+	}
+}
+
+func TestClamAVFound(t *testing.T) {
+	clamd := &fakeClamd{reply: func(content []byte) string {
+		if bytes.Contains(content, []byte("EICAR")) {
+			return "stream: Eicar-Test-Signature FOUND"
+		}
+		return "stream: OK"
+	}}
+	scanner := NewClamAV(startClamd(t, clamd), time.Second)
+
+	result, err := scanner.Scan(context.Background(), strings.NewReader("X5O!P%@AP EICAR test file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Clean || result.Signature != "Eicar-Test-Signature" {
+		t.Fatalf("result %+v, want the EICAR signature", result)
+	}
+}
+
+func TestClamAVErrorReplies(t *testing.T) {
+	replies := []string{
+		"stream: Can't allocate memory ERROR",
+		"stream: OK and something else",
+		"garbage",
+	}
+	for _, reply := range replies {
+		clamd := &fakeClamd{reply: func([]byte) string { return reply }}
+		scanner := NewClamAV(startClamd(t, clamd), time.Second)
+
+		result, err := scanner.Scan(context.Background(), strings.NewReader("content"))
+		if err == nil {
+			t.Fatalf("reply %q gave result %+v, want an error", reply, result)
+		}
+	}
+}
+
+func TestClamAVSizeLimit(t *testing.T) {
+	clamd := &fakeClamd{reply: clean, maxSize: clamChunkSize}
+	scanner := NewClamAV(startClamd(t, clamd), time.Second)
+
+	// The daemon replies and closes the connection while the rest of the content is still being sent
+	content := bytes.Repeat([]byte{'x'}, 64*clamChunkSize)
+	_, err := scanner.Scan(context.Background(), bytes.NewReader(content))
+	if err == nil || !strings.Contains(err.Error(), "size limit exceeded") {
+		t.Fatalf("Scan returned %v, want the size limit reply", err)
+	}
+}
+
+func TestClamAVTimeout(t *testing.T) {
+	clamd := &fakeClamd{stall: true}
+	scanner := NewClamAV(startClamd(t, clamd), 100*time.Millisecond)
+
+	started := time.Now()
+	if _, err := scanner.Scan(context.Background(), strings.NewReader("content")); err == nil {
+		t.Fatal("Scan of a stalled daemon succeeded")
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("Scan returned after %v, want the 100ms timeout", elapsed)
+	}
+}
+
+func TestClamAVUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	if _, err := NewClamAV(address, time.Second).Scan(context.Background(), strings.NewReader("content")); err == nil {
+		t.Fatal("Scan without a daemon succeeded")
+	}
+}
@@ -0,0 +1,50 @@
+package scan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Result is the verdict of a scanner about some content
+type Result struct {
+	// Clean is false when malware was found
+	Clean bool
+	// Signature names the malware found
+	Signature string
+}
+
+// Scanner checks uploaded content for malware
+type Scanner interface {
+	// Scan reads the content to the end, an error means the content could not be checked
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Nop reports every content as clean without reading it
+type Nop struct{}
+
+func (Nop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	return Result{Clean: true}, nil
+}
+
+// Config selects and configures the scanner
+type Config struct {
+	// Backend is "none" or "clamav"
+	Backend string
+	// Address is the host:port of the clamd TCP socket
+	Address string
+	// Timeout limits a whole scan, including the connection
+	Timeout time.Duration
+}
+
+// Open creates the scanner selected by the config
+func Open(config Config) (Scanner, error) {
+	switch config.Backend {
+	case "", "none":
+		return Nop{}, nil
+	case "clamav":
+		return NewClamAV(config.Address, config.Timeout), nil
+	}
+	return nil, fmt.Errorf("unknown scanner backend %q", config.Backend)
+}
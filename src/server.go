@@ -1,13 +1,21 @@
 package alexchatapp
 
 import (
+	"alexchatapp/src/blob"
 	"alexchatapp/src/data"
+	"alexchatapp/src/hub"
 	"alexchatapp/src/jwt"
 	pba "alexchatapp/src/proto/auth"
+	pbc "alexchatapp/src/proto/chat"
+	pbm "alexchatapp/src/proto/media"
 	pbp "alexchatapp/src/proto/profiles"
+	"alexchatapp/src/pubsub"
+	"alexchatapp/src/scan"
+	"context"
 	"log"
 	"net"
 	"os"
+	"path/filepath"
 
 	"github.com/joho/godotenv"
 
@@ -35,18 +43,66 @@ func Server() {
 	chat_repo := data.NewChatRepository(db)
 	auth_repo := data.NewUsersRepository(db)
 	profile_repo := data.NewProfilesRepository(db)
+	media_repo := data.NewMediaRepository(db)
 
 	// Create authentication server
 	authServer := NewAuthServer(chat_repo, auth_repo, profile_repo, &jwt_key)
-	profileServer := NewProfilesServer(profile_repo)
+	profileServer := NewProfilesServer(profile_repo, media_repo)
+
+	// Create chat hub, slow streams are disconnected unless configured otherwise
+	policy := hub.PolicyDisconnect
+	if value := os.Getenv("CHAT_SLOW_CONSUMER_POLICY"); value != "" {
+		var ok bool
+		policy, ok = hub.ParsePolicy(value)
+		if !ok {
+			log.Fatalf("Unknown CHAT_SLOW_CONSUMER_POLICY value: %s", value)
+		}
+	}
+	chat_hub := hub.NewHub(hub.DefaultBufferSize, policy)
+
+	// Create pub/sub broker, Postgres is required when running several nodes
+	var broker pubsub.Broker
+	switch os.Getenv("CHAT_PUBSUB") {
+	case "memory":
+		broker = pubsub.NewMemoryBroker()
+	case "", "postgres":
+		broker = pubsub.NewPostgresBroker(dsn, data.NewChatEventsRepository(db))
+	default:
+		log.Fatalf("Unknown CHAT_PUBSUB value: %s", os.Getenv("CHAT_PUBSUB"))
+	}
+
+	chatServer := NewChatServer(chat_repo, auth_repo, media_repo, chat_hub, broker, LoadChatConfig())
+	go broker.Run(context.Background(), chatServer.DeliverEvent)
+
+	// Create media server, unfinished uploads stay on local disk whatever store keeps completed ones
+	media_config := LoadMediaConfig()
+	store, err := blob.Open(media_config.Store)
+	if err != nil {
+		log.Fatalf("Media storage error: %v", err)
+	}
+	staging, err := blob.NewStaging(filepath.Join(media_config.Dir, "uploads"))
+	if err != nil {
+		log.Fatalf("Media storage error: %v", err)
+	}
+	scanner, err := scan.Open(media_config.Scanner)
+	if err != nil {
+		log.Fatalf("Media scanner error: %v", err)
+	}
+	mediaServer := NewMediaServer(media_repo, store, staging, scanner, media_config, chatServer.PublishScanResult)
+	chatServer.SetInlineStore(mediaServer.StoreInline)
+	go mediaServer.RunGarbageCollector(context.Background())
+	go mediaServer.RunScanner(context.Background())
 
 	// Create gRPC server
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(jwt.JWTUnaryInterceptor()),
+		grpc.StreamInterceptor(jwt.JWTStreamInterceptor()),
 	)
 
 	pba.RegisterAuthServiceServer(grpcServer, authServer)
 	pbp.RegisterProfileServiceServer(grpcServer, profileServer)
+	pbc.RegisterChatServiceServer(grpcServer, chatServer)
+	pbm.RegisterMediaServiceServer(grpcServer, mediaServer)
 
 	// Start server
 	listener, err := net.Listen("tcp", ":50051")
@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pba "alexchatapp/src/proto/auth"
+	pb "alexchatapp/src/proto/chat"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// ChatClientExample demonstrates chat service client usage
+func ChatClientExample() {
+	// Connect to server
+	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Connection error: %v", err)
+	}
+	defer conn.Close()
+
+	authClient := pba.NewAuthServiceClient(conn)
+	chatClient := pb.NewChatServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	// Login to get JWT token
+	log.Println("Logging in to get JWT token...")
+	loginResp, err := authClient.Login(ctx, &pba.LoginRequest{
+		Username: "testuser", // Make sure this user exists
+		Password: "password123",
+	})
+	if err != nil {
+		log.Fatalf("Login error: %v", err)
+	}
+
+	if !loginResp.Success {
+		log.Fatalf("Login failed: %s", loginResp.ErrorText)
+	}
+
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + loginResp.Token,
+	})
+	authCtx := metadata.NewOutgoingContext(ctx, md)
+
+	// === 1. Creating a chat ===
+	log.Println("Creating chat...")
+	createResp, err := chatClient.CreateChat(authCtx, &pb.CreateChatRequest{
+		Name: "Test chat",
+	})
+	if err != nil {
+		log.Fatalf("CreateChat error: %v", err)
+	}
+	log.Printf("✅ Chat created: %s", createResp.ChatId)
+
+	// === 2. Listing chats ===
+	log.Println("Fetching chats...")
+	chatsResp, err := chatClient.GetChats(authCtx, &pb.GetChatsRequest{})
+	if err != nil {
+		log.Fatalf("GetChats error: %v", err)
+	}
+	for _, chat := range chatsResp.Chats {
+		log.Printf("   Chat %s: %s", chat.Id, chat.Name)
+	}
+
+	// === 3. Fetching message history ===
+	log.Println("Fetching messages...")
+	messagesResp, err := chatClient.GetMessages(authCtx, &pb.GetMessagesRequest{
+		ChatId: createResp.ChatId,
+		Count:  20,
+	})
+	if err != nil {
+		log.Fatalf("GetMessages error: %v", err)
+	}
+	for _, message := range messagesResp.Messages {
+		log.Printf("   [%s] %s: %s", message.Id, message.SenderId, message.GetText())
+	}
+
+	// === 4. Sending a message over the stream ===
+	log.Println("Sending message over ChatStream...")
+	stream, err := chatClient.ChatStream(authCtx)
+	if err != nil {
+		log.Fatalf("ChatStream error: %v", err)
+	}
+
+	err = stream.Send(&pb.ClientEvent{
+		CorrelationId: "example-1",
+		Event: &pb.ClientEvent_Message{Message: &pb.ChatMessage{
+			ChatId:  createResp.ChatId,
+			Content: &pb.ChatMessage_Text{Text: "Hello from the example!"},
+		}},
+	})
+	if err != nil {
+		log.Fatalf("Send error: %v", err)
+	}
+
+	// The message itself and the ack for it arrive in any order
+	for received := 0; received < 2; received++ {
+		event, err := stream.Recv()
+		if err != nil {
+			log.Fatalf("Recv error: %v", err)
+		}
+
+		switch e := event.Event.(type) {
+		case *pb.ServerEvent_Message:
+			log.Printf("   Message %s: %s", e.Message.Id, e.Message.GetText())
+		case *pb.ServerEvent_Ack:
+			log.Printf("✅ Message acknowledged (%s): %s", event.CorrelationId, e.Ack.MessageId)
+		case *pb.ServerEvent_Error:
+			log.Printf("❌ Stream error (%s): %s", event.CorrelationId, e.Error.Message)
+		}
+	}
+	stream.CloseSend()
+
+	log.Println("✅ All chat service tests completed!")
+}
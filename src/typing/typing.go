@@ -0,0 +1,156 @@
+package typing
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTimeout is how long a typing indicator stays on without a new start event
+	DefaultTimeout = 5 * time.Second
+
+	// Every user may send up to rateBurst typing events at once, refilled at one per rateInterval
+	rateBurst    = 10
+	rateInterval = 500 * time.Millisecond
+)
+
+// NotifyFunc is called whenever the typing state of a user in a chat changes
+type NotifyFunc func(chat_id, user_id uint, typing bool)
+
+type key struct {
+	chatID uint
+	userID uint
+}
+
+// state is an active typing indicator
+type state struct {
+	timer    *time.Timer
+	deadline time.Time
+}
+
+// bucket is a token bucket limiting typing events of one user
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Tracker keeps ephemeral typing states in memory and turns them off when no stop arrives in time.
+// Nothing is persisted, states are lost with the node that tracks them.
+type Tracker struct {
+	mu      sync.Mutex
+	active  map[key]*state
+	buckets map[uint]*bucket
+	timeout time.Duration
+	notify  NotifyFunc
+}
+
+// NewTracker creates a tracker calling notify on every state change
+func NewTracker(timeout time.Duration, notify NotifyFunc) *Tracker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Tracker{
+		active:  make(map[key]*state),
+		buckets: make(map[uint]*bucket),
+		timeout: timeout,
+		notify:  notify,
+	}
+}
+
+// Allow takes a token from the user's bucket, false means the event must be rejected
+func (t *Tracker) Allow(user_id uint) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	b, ok := t.buckets[user_id]
+	if !ok {
+		b = &bucket{tokens: rateBurst, last: now}
+		t.buckets[user_id] = b
+	}
+
+	b.tokens += float64(now.Sub(b.last)) / float64(rateInterval)
+	if b.tokens > rateBurst {
+		b.tokens = rateBurst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Start marks the user as typing in the chat. Repeated starts only extend the timeout.
+func (t *Tracker) Start(chat_id, user_id uint) {
+	k := key{chatID: chat_id, userID: user_id}
+
+	t.mu.Lock()
+	if st, ok := t.active[k]; ok {
+		st.deadline = time.Now().Add(t.timeout)
+		st.timer.Reset(t.timeout)
+		t.mu.Unlock()
+		return
+	}
+
+	st := &state{deadline: time.Now().Add(t.timeout)}
+	st.timer = time.AfterFunc(t.timeout, func() {
+		t.expire(k, st)
+	})
+	t.active[k] = st
+	t.mu.Unlock()
+
+	t.notify(chat_id, user_id, true)
+}
+
+// Stop marks the user as not typing in the chat
+func (t *Tracker) Stop(chat_id, user_id uint) {
+	k := key{chatID: chat_id, userID: user_id}
+
+	t.mu.Lock()
+	st, ok := t.active[k]
+	if ok {
+		st.timer.Stop()
+		delete(t.active, k)
+	}
+	t.mu.Unlock()
+
+	if ok {
+		t.notify(chat_id, user_id, false)
+	}
+}
+
+// StopAll stops every typing state of the user, used when the user disconnects
+func (t *Tracker) StopAll(user_id uint) {
+	var stopped []uint
+
+	t.mu.Lock()
+	for k, st := range t.active {
+		if k.userID == user_id {
+			st.timer.Stop()
+			delete(t.active, k)
+			stopped = append(stopped, k.chatID)
+		}
+	}
+	delete(t.buckets, user_id)
+	t.mu.Unlock()
+
+	for _, chat_id := range stopped {
+		t.notify(chat_id, user_id, false)
+	}
+}
+
+// expire turns the state off unless it was stopped or extended in the meantime
+func (t *Tracker) expire(k key, st *state) {
+	t.mu.Lock()
+	current, ok := t.active[k]
+	if !ok || current != st || time.Now().Before(st.deadline) {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.active, k)
+	t.mu.Unlock()
+
+	t.notify(k.chatID, k.userID, false)
+}
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var mentionRegex = regexp.MustCompile(`(?:^|\s)@([a-zA-Z0-9_-]{3,50})`)
+
+var urlRegex = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+`)
+
+// maxURLLength is the longest link ExtractURLs returns
+const maxURLLength = 2048
+
+// ValidateChatName validates chat name format
+func ValidateChatName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("chat name is required")
+	}
+	if utf8.RuneCountInString(name) > 100 {
+		return errors.New("chat name must not exceed 100 characters")
+	}
+	return nil
+}
+
+// ValidateChatDescription validates an optional chat description
+func ValidateChatDescription(description string) error {
+	if utf8.RuneCountInString(description) > 500 {
+		return errors.New("chat description must not exceed 500 characters")
+	}
+	return nil
+}
+
+// ValidateMessageText validates text message content
+func ValidateMessageText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("message text must not be empty")
+	}
+	if utf8.RuneCountInString(text) > 4096 {
+		return errors.New("message text must not exceed 4096 characters")
+	}
+	return nil
+}
+
+// ValidateSearchQuery validates the text of a message search
+func ValidateSearchQuery(query string) error {
+	if strings.TrimSpace(query) == "" {
+		return errors.New("search query is required")
+	}
+	if utf8.RuneCountInString(query) > 256 {
+		return errors.New("search query must not exceed 256 characters")
+	}
+	return nil
+}
+
+// ParseID converts a string identifier from proto messages into a database ID
+func ParseID(id string) (uint, error) {
+	value, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || value == 0 {
+		return 0, errors.New("invalid id: " + id)
+	}
+	return uint(value), nil
+}
+
+// FormatID converts a database ID into a string identifier for proto messages
+func FormatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+// ExtractMentions returns unique usernames mentioned with @username in the text
+func ExtractMentions(text string) []string {
+	var usernames []string
+	seen := map[string]bool{}
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			usernames = append(usernames, match[1])
+		}
+	}
+	return usernames
+}
+
+// ExtractURLs returns up to limit unique http and https links found in the text, in order.
+// Punctuation ending a sentence is not part of a link, neither is a closing bracket opened before it.
+func ExtractURLs(text string, limit int) []string {
+	var urls []string
+	seen := map[string]bool{}
+	for _, match := range urlRegex.FindAllString(text, -1) {
+		for {
+			trimmed := strings.TrimRight(match, ".,;:!?'*")
+			if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, "(") < strings.Count(trimmed, ")") {
+				trimmed = trimmed[:len(trimmed)-1]
+			}
+			if trimmed == match {
+				break
+			}
+			match = trimmed
+		}
+
+		if len(match) > maxURLLength || seen[match] {
+			continue
+		}
+		seen[match] = true
+		urls = append(urls, match)
+		if len(urls) == limit {
+			break
+		}
+	}
+	return urls
+}
+
+// TruncateText shortens the text to at most limit characters, adding an ellipsis when cut
+func TruncateText(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:limit-1]) + "…"
+}
+
+// ValidateEmoji validates a reaction emoji. Only the size and shape are checked,
+// so composed sequences like flags and skin tones are accepted.
+func ValidateEmoji(emoji string) error {
+	if emoji == "" {
+		return errors.New("emoji is required")
+	}
+	if !utf8.ValidString(emoji) || len(emoji) > 64 || utf8.RuneCountInString(emoji) > 16 {
+		return errors.New("invalid emoji")
+	}
+	// Plain text is not a reaction, keycap sequences like 1️⃣ still start with an ASCII digit
+	symbol := false
+	for _, r := range emoji {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return errors.New("invalid emoji")
+		}
+		if r >= utf8.RuneSelf {
+			symbol = true
+		}
+	}
+	if !symbol {
+		return errors.New("invalid emoji")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"encoding/hex"
+	"errors"
+	"mime"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ValidateContentHash validates a lowercase hex SHA-256
+func ValidateContentHash(hash string) error {
+	if len(hash) != 64 || strings.ToLower(hash) != hash {
+		return errors.New("sha256 must be 64 lowercase hex characters")
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return errors.New("sha256 must be 64 lowercase hex characters")
+	}
+	return nil
+}
+
+// ValidateMimeType validates a declared type/subtype MIME type without parameters
+func ValidateMimeType(mimeType string) error {
+	if mimeType == "" {
+		return errors.New("mime type is required")
+	}
+	if len(mimeType) > 128 {
+		return errors.New("mime type must not exceed 128 characters")
+	}
+	parsed, params, err := mime.ParseMediaType(mimeType)
+	if err != nil || len(params) > 0 || parsed != mimeType || !strings.Contains(parsed, "/") {
+		return errors.New("invalid mime type")
+	}
+	return nil
+}
+
+// ValidateFilename validates the name of an attached file, directories are not allowed
+func ValidateFilename(filename string) error {
+	if strings.TrimSpace(filename) == "" {
+		return errors.New("filename is required")
+	}
+	if len(filename) > 255 {
+		return errors.New("filename must not exceed 255 bytes")
+	}
+	if !utf8.ValidString(filename) || filename == "." || filename == ".." {
+		return errors.New("invalid filename")
+	}
+	for _, r := range filename {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return errors.New("filename must not contain slashes or control characters")
+		}
+	}
+	return nil
+}
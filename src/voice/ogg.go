@@ -0,0 +1,121 @@
+package voice
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var errTruncated = errors.New("unexpected end of data")
+
+// opusSampleRate is the rate of Ogg Opus granule positions whatever the input rate was
+const opusSampleRate = 48000
+
+// oggPageHeaderSize is the size of a page header without its segment table
+const oggPageHeaderSize = 27
+
+// analyzeOgg reads an Ogg Opus stream. Opus is not decoded, the waveform follows the size of the audio packets,
+// which grows with loudness and drops to a few bytes for silence.
+func analyzeOgg(data []byte) (*Audio, error) {
+	var (
+		serial   uint32
+		packets  int
+		packet   []byte
+		preSkip  uint64
+		granule  uint64
+		levels   []float64
+		finished bool
+	)
+
+	for i := 0; i < len(data); {
+		if i+oggPageHeaderSize > len(data) {
+			return nil, errTruncated
+		}
+		if string(data[i:i+4]) != "OggS" || data[i+4] != 0 {
+			return nil, errors.New("broken Ogg page")
+		}
+		pageGranule := binary.LittleEndian.Uint64(data[i+6:])
+		pageSerial := binary.LittleEndian.Uint32(data[i+14:])
+		segments := int(data[i+26])
+
+		tableEnd := i + oggPageHeaderSize + segments
+		if tableEnd > len(data) {
+			return nil, errTruncated
+		}
+		table := data[i+oggPageHeaderSize : tableEnd]
+		bodyEnd := tableEnd
+		for _, lacing := range table {
+			bodyEnd += int(lacing)
+		}
+		if bodyEnd > len(data) {
+			return nil, errTruncated
+		}
+
+		if i == 0 {
+			serial = pageSerial
+		}
+		// Other logical streams multiplexed into the file are skipped
+		if pageSerial != serial {
+			i = bodyEnd
+			continue
+		}
+		if finished {
+			return nil, errors.New("Ogg stream continues after its last page")
+		}
+
+		offset := tableEnd
+		for _, lacing := range table {
+			packet = append(packet, data[offset:offset+int(lacing)]...)
+			offset += int(lacing)
+			if lacing == 255 {
+				continue
+			}
+
+			switch packets {
+			case 0:
+				if len(packet) < 19 || !bytes.HasPrefix(packet, []byte("OpusHead")) {
+					return nil, ErrUnsupportedFormat
+				}
+				if packet[8]&0xF0 != 0 || packet[9] == 0 {
+					return nil, errors.New("unsupported Opus header")
+				}
+				preSkip = uint64(binary.LittleEndian.Uint16(packet[10:]))
+			case 1:
+				if !bytes.HasPrefix(packet, []byte("OpusTags")) {
+					return nil, errors.New("missing Opus tags")
+				}
+			default:
+				levels = append(levels, float64(len(packet)))
+			}
+			packets++
+			packet = packet[:0]
+		}
+
+		// Pages where no packet ends have no granule position
+		if pageGranule != ^uint64(0) {
+			granule = pageGranule
+		}
+		// The end of stream flag
+		finished = data[i+5]&0x04 != 0
+		i = bodyEnd
+	}
+
+	if packets == 0 {
+		return nil, ErrUnsupportedFormat
+	}
+	if len(levels) == 0 || granule <= preSkip {
+		return nil, errors.New("recording has no audio")
+	}
+	// Keeps the duration far from overflowing, no recording is that long
+	if granule > 1<<48 {
+		return nil, errors.New("broken Ogg granule position")
+	}
+
+	samples := granule - preSkip
+	return &Audio{
+		MimeType: "audio/ogg",
+		Duration: time.Duration(samples/opusSampleRate)*time.Second + time.Duration(samples%opusSampleRate)*time.Second/opusSampleRate,
+		Waveform: waveform(levels),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package voice
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// WaveformSamples is the number of waveform values computed for a recording
+const WaveformSamples = 100
+
+// ErrUnsupportedFormat is returned for content that is neither Ogg Opus nor PCM WAV
+var ErrUnsupportedFormat = errors.New("voice must be Ogg Opus or PCM WAV")
+
+// Audio describes a validated voice recording
+type Audio struct {
+	// MimeType is detected from the content
+	MimeType string
+	Duration time.Duration
+	// Waveform holds WaveformSamples loudness values from 0 to 255, scaled to the loudest one
+	Waveform []byte
+}
+
+// Analyze validates the recording and computes its duration and waveform.
+// ErrUnsupportedFormat is returned for other containers and codecs, other errors mean the content is broken.
+func Analyze(data []byte) (*Audio, error) {
+	var (
+		audio *Audio
+		err   error
+	)
+	switch MimeType(data) {
+	case "audio/ogg":
+		audio, err = analyzeOgg(data)
+	case "audio/wav":
+		audio, err = analyzeWAV(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	if err != nil && !errors.Is(err, ErrUnsupportedFormat) {
+		return nil, fmt.Errorf("recording is corrupt: %w", err)
+	}
+	return audio, err
+}
+
+// MimeType detects the container of a recording from its header, it is empty for other content
+func MimeType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("OggS")):
+		return "audio/ogg"
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return "audio/wav"
+	}
+	return ""
+}
+
+// waveform reduces the levels to WaveformSamples values by taking the loudest level of every bucket,
+// recordings shorter than that keep one value per level
+func waveform(levels []float64) []byte {
+	count := min(len(levels), WaveformSamples)
+	buckets := make([]float64, count)
+	for i, level := range levels {
+		bucket := i * count / len(levels)
+		buckets[bucket] = max(buckets[bucket], level)
+	}
+
+	loudest := 0.0
+	for _, level := range buckets {
+		loudest = max(loudest, level)
+	}
+
+	result := make([]byte, count)
+	if loudest == 0 {
+		return result
+	}
+	for i, level := range buckets {
+		result[i] = byte(level / loudest * 255)
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+package voice
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// WAV sample formats
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+type wavFormat struct {
+	format     uint16
+	channels   int
+	sampleRate int
+	blockAlign int
+	bits       int
+}
+
+// analyzeWAV reads integer PCM or float WAV, the waveform uses the peak sample of every bucket
+func analyzeWAV(data []byte) (*Audio, error) {
+	var (
+		format  *wavFormat
+		samples []byte
+	)
+
+	// Streaming encoders leave the sizes unset, chunks are read as far as the data goes
+	for i := 12; i+8 <= len(data) && samples == nil; {
+		id := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		start := i + 8
+		end := start + size
+		if size < 0 || end > len(data) || end < start {
+			end = len(data)
+		}
+
+		switch id {
+		case "fmt ":
+			parsed, err := parseWAVFormat(data[start:end])
+			if err != nil {
+				return nil, err
+			}
+			format = parsed
+		case "data":
+			if format == nil {
+				return nil, errors.New("WAV data comes before its format")
+			}
+			samples = data[start:end]
+		}
+		i = end + size%2
+	}
+
+	if format == nil {
+		return nil, errors.New("missing WAV format")
+	}
+	frames := len(samples) / format.blockAlign
+	if frames == 0 {
+		return nil, errors.New("recording has no audio")
+	}
+
+	levels := make([]float64, min(frames, WaveformSamples))
+	bytesPerSample := format.bits / 8
+	for frame := 0; frame < frames; frame++ {
+		bucket := frame * len(levels) / frames
+		for channel := 0; channel < format.channels; channel++ {
+			offset := frame*format.blockAlign + channel*bytesPerSample
+			level := sampleLevel(samples[offset:offset+bytesPerSample], format)
+			levels[bucket] = max(levels[bucket], level)
+		}
+	}
+
+	return &Audio{
+		MimeType: "audio/wav",
+		Duration: time.Duration(frames/format.sampleRate)*time.Second +
+			time.Duration(frames%format.sampleRate)*time.Second/time.Duration(format.sampleRate),
+		Waveform: waveform(levels),
+	}, nil
+}
+
+func parseWAVFormat(chunk []byte) (*wavFormat, error) {
+	if len(chunk) < 16 {
+		return nil, errTruncated
+	}
+	format := &wavFormat{
+		format:     binary.LittleEndian.Uint16(chunk[0:]),
+		channels:   int(binary.LittleEndian.Uint16(chunk[2:])),
+		sampleRate: int(binary.LittleEndian.Uint32(chunk[4:])),
+		blockAlign: int(binary.LittleEndian.Uint16(chunk[12:])),
+		bits:       int(binary.LittleEndian.Uint16(chunk[14:])),
+	}
+	// Extensible formats carry the actual format in the first bytes of the sub format GUID
+	if format.format == wavFormatExtensible {
+		if len(chunk) < 26 {
+			return nil, errTruncated
+		}
+		format.format = binary.LittleEndian.Uint16(chunk[24:])
+	}
+
+	switch {
+	case format.format == wavFormatPCM && (format.bits == 8 || format.bits == 16 || format.bits == 24 || format.bits == 32):
+	case format.format == wavFormatFloat && format.bits == 32:
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if format.channels == 0 || format.sampleRate == 0 || format.blockAlign != format.channels*format.bits/8 {
+		return nil, errors.New("broken WAV format")
+	}
+	return format, nil
+}
+
+// sampleLevel returns the absolute value of a little endian sample, from 0 to 1
+func sampleLevel(sample []byte, format *wavFormat) float64 {
+	if format.format == wavFormatFloat {
+		value := float64(math.Float32frombits(binary.LittleEndian.Uint32(sample)))
+		if math.IsNaN(value) {
+			return 0
+		}
+		return min(math.Abs(value), 1)
+	}
+
+	switch format.bits {
+	case 8:
+		// 8-bit samples are unsigned
+		return math.Abs(float64(int(sample[0])-128)) / 128
+	case 16:
+		return math.Abs(float64(int16(binary.LittleEndian.Uint16(sample)))) / (1 << 15)
+	case 24:
+		value := int32(uint32(sample[0])<<8|uint32(sample[1])<<16|uint32(sample[2])<<24) >> 8
+		return math.Abs(float64(value)) / (1 << 23)
+	}
+	return math.Abs(float64(int32(binary.LittleEndian.Uint32(sample)))) / (1 << 31)
+}